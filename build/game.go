@@ -2,6 +2,7 @@ package build
 
 import (
 	"fmt"
+	"time"
 
 	"kazuki.matsumoto/reversi/game"
 	"kazuki.matsumoto/reversi/gen/pb"
@@ -9,19 +10,94 @@ import (
 
 func Room(r *pb.Room) *game.Room {
 	return &game.Room{
-		ID:    r.GetId(),
-		Host:  Player(r.GetHost()),
-		Guest: Player(r.GetGuest()),
+		ID:              r.GetId(),
+		Host:            Player(r.GetHost()),
+		Guest:           Player(r.GetGuest()),
+		TimeControl:     TimeControl(r.GetTimeControl()),
+		Variant:         Variant(r.GetVariant()),
+		Visibility:      Visibility(r.GetVisibility()),
+		AllowSpectators: r.GetAllowSpectators(),
+		InviteCode:      r.GetInviteCode(),
 	}
 }
 
+func Visibility(v pb.Visibility) game.Visibility {
+	if v == pb.Visibility_PRIVATE {
+		return game.Private
+	}
+	return game.Public
+}
+
+func Variant(v pb.Variant) game.Variant {
+	switch v {
+	case pb.Variant_STANDARD:
+		return game.Standard
+	}
+	return game.UnknownVariant
+}
+
+func TimeControl(tc *pb.TimeControl) game.TimeControl {
+	return game.TimeControl{
+		Kind:      TimeControlKind(tc.GetKind()),
+		Main:      durationMs(tc.GetMainMs()),
+		Increment: durationMs(tc.GetIncrementMs()),
+		Byoyomi:   durationMs(tc.GetByoyomiMs()),
+	}
+}
+
+func TimeControlKind(k pb.TimeControl_Kind) game.TimeControlKind {
+	switch k {
+	case pb.TimeControl_ABSOLUTE:
+		return game.Absolute
+	case pb.TimeControl_FISCHER:
+		return game.Fischer
+	case pb.TimeControl_BYOYOMI:
+		return game.Byoyomi
+	}
+	return game.NoTimeControl
+}
+
+// durationMs ミリ秒をtime.Durationに変換する
+func durationMs(ms int64) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
+
 func Player(p *pb.Player) *game.Player {
 	return &game.Player{
 		ID:        p.GetId(),
 		Character: Character(p.GetCharacter()),
+		Bot:       p.GetBot(),
+		Name:      p.GetName(),
+	}
+}
+
+func Ply(p *pb.Ply) game.Ply {
+	return game.Ply{
+		Character: Character(p.GetCharacter()),
+		X:         p.GetMove().GetX(),
+		Y:         p.GetMove().GetY(),
+		Pass:      p.GetPass(),
 	}
 }
 
+func Termination(t pb.PlayResponse_FinishedEvent_Termination) game.Termination {
+	switch t {
+	case pb.PlayResponse_FinishedEvent_COMPLETED:
+		return game.Completed
+	case pb.PlayResponse_FinishedEvent_RESIGNED:
+		return game.Resigned
+	case pb.PlayResponse_FinishedEvent_TIME_FORFEIT:
+		return game.TimeForfeit
+	case pb.PlayResponse_FinishedEvent_DRAW_AGREED:
+		return game.DrawAgreed
+	case pb.PlayResponse_FinishedEvent_ABORTED:
+		return game.Aborted
+	case pb.PlayResponse_FinishedEvent_ABANDONED:
+		return game.Abandoned
+	}
+	return game.NotTerminated
+}
+
 func Character(c pb.Character) game.Character {
 	switch c {
 	case pb.Character_BLACK:
@@ -32,7 +108,22 @@ func Character(c pb.Character) game.Character {
 		return game.Empty
 	case pb.Character_WALL:
 		return game.Wall
+	case pb.Character_NONE, pb.Character_UNKNOWN:
+		// 再接続時のようにプレイヤーを含まないリクエストもあるので、未指定は色なしとして扱う
+		return game.None
 	}
 
 	panic(fmt.Sprintf("unknwon color=%v", c))
 }
+
+// Rewards 公開された提供割合を、抽選をやり直せる形にする
+func Rewards(items []*pb.OddsItem) []*game.Reward {
+	rewards := make([]*game.Reward, 0, len(items))
+	for _, item := range items {
+		rewards = append(rewards, &game.Reward{
+			CardID: item.GetCardId(),
+			Ratio:  int(item.GetRatio()),
+		})
+	}
+	return rewards
+}
@@ -7,9 +7,70 @@ import (
 
 func PBRoom(r *game.Room) *pb.Room {
 	return &pb.Room{
-		Id:    r.ID,
-		Host:  PBPlayer(r.Host),
-		Guest: PBPlayer(r.Guest),
+		Id:              r.ID,
+		Host:            PBPlayer(r.Host),
+		Guest:           PBPlayer(r.Guest),
+		TimeControl:     PBTimeControl(r.TimeControl),
+		Variant:         PBVariant(r.Variant),
+		Visibility:      PBVisibility(r.Visibility),
+		AllowSpectators: r.AllowSpectators,
+		InviteCode:      r.InviteCode,
+	}
+}
+
+func PBVisibility(v game.Visibility) pb.Visibility {
+	if v == game.Private {
+		return pb.Visibility_PRIVATE
+	}
+	return pb.Visibility_PUBLIC
+}
+
+// PBVariant 対応しているルールは通常のリバーシだけなので、常にSTANDARD
+func PBVariant(v game.Variant) pb.Variant {
+	return pb.Variant_STANDARD
+}
+
+func PBTimeControl(tc game.TimeControl) *pb.TimeControl {
+	if !tc.Enabled() {
+		return nil
+	}
+	return &pb.TimeControl{
+		Kind:        PBTimeControlKind(tc.Kind),
+		MainMs:      tc.Main.Milliseconds(),
+		IncrementMs: tc.Increment.Milliseconds(),
+		ByoyomiMs:   tc.Byoyomi.Milliseconds(),
+	}
+}
+
+func PBTimeControlKind(k game.TimeControlKind) pb.TimeControl_Kind {
+	switch k {
+	case game.Absolute:
+		return pb.TimeControl_ABSOLUTE
+	case game.Fischer:
+		return pb.TimeControl_FISCHER
+	case game.Byoyomi:
+		return pb.TimeControl_BYOYOMI
+	}
+	return pb.TimeControl_NONE
+}
+
+// PBClocks 時計の現在の残り時間。持ち時間がない部屋ではnil
+func PBClocks(c *game.Clock) *pb.Clocks {
+	if c == nil {
+		return nil
+	}
+	return &pb.Clocks{
+		Black:   PBClock(c, game.Black),
+		White:   PBClock(c, game.White),
+		Running: PBCharacter(c.Running()),
+	}
+}
+
+func PBClock(c *game.Clock, ch game.Character) *pb.Clock {
+	left, byoyomi := c.Remaining(ch)
+	return &pb.Clock{
+		RemainingMs: left.Milliseconds(),
+		Byoyomi:     byoyomi,
 	}
 }
 
@@ -20,6 +81,8 @@ func PBPlayer(p *game.Player) *pb.Player {
 	return &pb.Player{
 		Id:        p.ID,
 		Character: PBCharacter(p.Character),
+		Bot:       p.Bot,
+		Name:      p.Name,
 	}
 }
 
@@ -33,19 +96,40 @@ func PBCharacter(c game.Character) pb.Character {
 		return pb.Character_EMPTY
 	case game.Wall:
 		return pb.Character_WALL
+	case game.None:
+		return pb.Character_NONE
 	}
 	return pb.Character_UNKNOWN
 }
 
-func PBBoard(b *game.Board) *pb.Board {
+func PBPly(p game.Ply) *pb.Ply {
+	ply := &pb.Ply{
+		Character: PBCharacter(p.Character),
+		Pass:      p.Pass,
+	}
+	if !p.Pass {
+		ply.Move = &pb.Move{X: p.X, Y: p.Y}
+	}
+	return ply
+}
+
+func PBPlies(ps []game.Ply) []*pb.Ply {
+	plies := make([]*pb.Ply, 0, len(ps))
+	for _, p := range ps {
+		plies = append(plies, PBPly(p))
+	}
+	return plies
+}
+
+func PBBoard(b game.Boarder) *pb.Board {
 	// 列
-	pbCols := make([]*pb.Board_Col, 0, 10)
-	// protobufで二次元配列を直接扱えないので、cellの数 -> colの数ぶんpbCellsを定義。
-	for _, col := range b.Cells {
-		pbCells := make([]pb.Character, 0, 10)
+	pbCols := make([]*pb.Board_Col, 0, game.BoardSize+2)
+	// protobufで二次元配列を直接扱えないので、cellの数 -> colの数ぶんpbCellsを定義。壁も含めて送る
+	for x := int32(0); x <= game.BoardSize+1; x++ {
+		pbCells := make([]pb.Character, 0, game.BoardSize+2)
 		// colも同様に行列を持つので、その数分配列を生成。要素をpbCellsにappendしていく。
-		for _, c := range col {
-			pbCells = append(pbCells, PBCharacter(c))
+		for y := int32(0); y <= game.BoardSize+1; y++ {
+			pbCells = append(pbCells, PBCharacter(b.Cell(x, y)))
 		}
 		// pbColsとpbCellsを結合し、二次元配列とする
 		pbCols = append(pbCols, &pb.Board_Col{
@@ -54,3 +138,31 @@ func PBBoard(b *game.Board) *pb.Board {
 	}
 	return &pb.Board{Cols: pbCols}
 }
+
+// PBFinishedEvent 終了した対局の結果
+func PBFinishedEvent(g *game.Game) *pb.PlayResponse_FinishedEvent {
+	return &pb.PlayResponse_FinishedEvent{
+		Winner:      PBCharacter(g.Winner()),
+		Board:       PBBoard(g.Board),
+		Termination: PBTermination(g.Termination()),
+		Loser:       PBCharacter(g.Loser()),
+	}
+}
+
+func PBTermination(t game.Termination) pb.PlayResponse_FinishedEvent_Termination {
+	switch t {
+	case game.Completed:
+		return pb.PlayResponse_FinishedEvent_COMPLETED
+	case game.Resigned:
+		return pb.PlayResponse_FinishedEvent_RESIGNED
+	case game.TimeForfeit:
+		return pb.PlayResponse_FinishedEvent_TIME_FORFEIT
+	case game.DrawAgreed:
+		return pb.PlayResponse_FinishedEvent_DRAW_AGREED
+	case game.Aborted:
+		return pb.PlayResponse_FinishedEvent_ABORTED
+	case game.Abandoned:
+		return pb.PlayResponse_FinishedEvent_ABANDONED
+	}
+	return pb.PlayResponse_FinishedEvent_UNKNOWN
+}
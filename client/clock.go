@@ -0,0 +1,74 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// 残り時間がこの秒数になったら知らせる
+var clockWarnings = map[int64]bool{30: true, 10: true, 5: true}
+
+// timeControlString 持ち時間の設定を"5m0s + 5s/move"のような形式で表す
+func timeControlString(tc game.TimeControl) string {
+	switch tc.Kind {
+	case game.Absolute:
+		return fmt.Sprintf("%v", tc.Main)
+	case game.Fischer:
+		return fmt.Sprintf("%v + %v/move", tc.Main, tc.Increment)
+	case game.Byoyomi:
+		return fmt.Sprintf("%v, byoyomi %v", tc.Main, tc.Byoyomi)
+	}
+	return "none"
+}
+
+// printClocks 双方の残り時間を表示する。持ち時間がない部屋では何もしない
+func printClocks(c *pb.Clocks) {
+	if c == nil {
+		return
+	}
+	fmt.Printf("Clock: BLACK=%v, WHITE=%v\n", clockString(c.GetBlack()), clockString(c.GetWhite()))
+}
+
+// warnClock 残り時間が少なくなったら表示する
+func warnClock(c *pb.Clocks, me game.Character) {
+	clock := c.GetBlack()
+	if me == game.White {
+		clock = c.GetWhite()
+	}
+	if sec := clock.GetRemainingMs() / 1000; clockWarnings[sec] {
+		fmt.Printf("\n%v left. Input Your Move (ex. A-1, undo):", clockString(clock))
+	}
+}
+
+func clockString(c *pb.Clock) string {
+	d := (time.Duration(c.GetRemainingMs()) * time.Millisecond).Truncate(time.Second)
+	if c.GetByoyomi() {
+		return fmt.Sprintf("%v (byoyomi)", d)
+	}
+	return d.String()
+}
+
+// ParseTimeControl コマンドライン引数から持ち時間の設定を作る
+func ParseTimeControl(kind string, main, increment, byoyomi time.Duration) (game.TimeControl, error) {
+	tc := game.TimeControl{
+		Main:      main,
+		Increment: increment,
+		Byoyomi:   byoyomi,
+	}
+	switch kind {
+	case "", "none":
+		return game.TimeControl{}, nil
+	case "absolute":
+		tc.Kind = game.Absolute
+	case "fischer":
+		tc.Kind = game.Fischer
+	case "byoyomi":
+		tc.Kind = game.Byoyomi
+	default:
+		return tc, fmt.Errorf("unknown clock %q: none, absolute, fischer, byoyomi", kind)
+	}
+	return tc, tc.Validate()
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// credentials 名前ごとのプレイヤートークン。同じ名前で参加し直す時に送り、登録したプロフィールで遊ぶ
+type credentials map[string]string
+
+// loadCredentials pathのファイルからプレイヤートークンを読み込む。ファイルがなければ空
+func loadCredentials(path string) (credentials, error) {
+	c := credentials{}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("invalid credentials file %v: %w", path, err)
+	}
+	return c, nil
+}
+
+// save pathのファイルに書き込む。他のユーザーに読まれると名前を使われるので、自分だけが読めるようにする
+func (c credentials) save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o600)
+}
+
+// playerToken 設定した名前を登録した時に受け取ったトークン。ゲストか、まだ登録していなければ空
+func (r *Reversi) playerToken() string {
+	if r.cfg.Name == "" || r.cfg.Credentials == "" {
+		return ""
+	}
+	c, err := loadCredentials(r.cfg.Credentials)
+	if err != nil {
+		fmt.Printf("Failed to load credentials: %v\n", err)
+		return ""
+	}
+	return c[r.cfg.Name]
+}
+
+// savePlayerToken 名前を登録したプロフィールのトークンを保存する。保存できなくても対局は続ける
+func (r *Reversi) savePlayerToken(name, token string) {
+	if r.cfg.Credentials == "" {
+		return
+	}
+	c, err := loadCredentials(r.cfg.Credentials)
+	if err == nil {
+		c[name] = token
+		err = c.save(r.cfg.Credentials)
+	}
+	if err != nil {
+		fmt.Printf("Failed to save credentials: %v\n", err)
+	}
+}
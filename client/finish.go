@@ -0,0 +1,79 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"kazuki.matsumoto/reversi/build"
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// commandRequest 手の代わりに入力されたコマンドのリクエスト。コマンドでなければnil
+func commandRequest(text string) *pb.PlayRequest {
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "resign":
+		return &pb.PlayRequest{Action: &pb.PlayRequest_Resign{Resign: &pb.ResignAction{}}}
+	case "draw":
+		return &pb.PlayRequest{Action: &pb.PlayRequest_OfferDraw{OfferDraw: &pb.OfferDrawAction{}}}
+	case "abort":
+		return &pb.PlayRequest{Action: &pb.PlayRequest_Abort{Abort: &pb.AbortAction{}}}
+	}
+	return nil
+}
+
+// printTermination 対局の終わり方を表示する。meは自分の色で、観戦者の場合はNone
+func printTermination(f *pb.PlayResponse_FinishedEvent, me game.Character) {
+	if s := terminationString(f, me); s != "" {
+		fmt.Println(s)
+	}
+}
+
+// terminationString 対局の終わり方。双方置ける場所がなくなった場合は空
+func terminationString(f *pb.PlayResponse_FinishedEvent, me game.Character) string {
+	loser := build.Character(f.GetLoser())
+	who := fmt.Sprintf("%v", f.GetLoser())
+	if me != game.None {
+		who = "Opponent"
+		if loser == me {
+			who = "You"
+		}
+	}
+
+	switch build.Termination(f.GetTermination()) {
+	case game.Resigned:
+		return fmt.Sprintf("%v resigned.", who)
+	case game.TimeForfeit:
+		return fmt.Sprintf("%v ran out of time.", who)
+	case game.DrawAgreed:
+		return "Draw agreed."
+	case game.Aborted:
+		return "Game aborted."
+	case game.Abandoned:
+		return fmt.Sprintf("%v left the game.", who)
+	}
+	return ""
+}
+
+// resultLines 対局者から見た結果。終わり方、勝敗、付与された報酬の順に並べる
+func resultLines(f *pb.PlayResponse_FinishedEvent, me *game.Player) []string {
+	var lines []string
+	if s := terminationString(f, me.Character); s != "" {
+		lines = append(lines, s)
+	}
+	winner := build.Character(f.GetWinner())
+	if build.Termination(f.GetTermination()) == game.Aborted {
+		// 中止の場合は勝敗をつけない
+	} else if winner == game.None {
+		lines = append(lines, "Draw!")
+	} else if winner == me.Character {
+		lines = append(lines, "You Win!")
+		// 報酬はサーバーが抽選して付与する
+		if reward := f.GetReward(); reward.GetPlayerId() == me.ID {
+			lines = append(lines, fmt.Sprintf("Reward: %v", reward.GetCardId()))
+		}
+	} else {
+		lines = append(lines, "You Lose!")
+	}
+	return lines
+}
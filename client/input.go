@@ -0,0 +1,168 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// inputInterval 入力を待っている間に、待っている内容が変わったかを確かめる間隔
+const inputInterval = 100 * time.Millisecond
+
+// awaiting 入力した1行を何として扱うか
+type awaiting int
+
+const (
+	awaitNone     awaiting = iota // 相手の手番か、待ったの返答待ち。入力は使わない
+	awaitTakeback                 // 相手からの待ったの申し込みへの返答
+	awaitDraw                     // 相手からの引き分けの申し込みへの返答
+	awaitMove                     // 自分の手かコマンド
+)
+
+// message 入力を促す文
+func (p awaiting) message() string {
+	switch p {
+	case awaitTakeback:
+		return "Opponent requests a takeback. Accept? (y/n):"
+	case awaitDraw:
+		return "Opponent offers a draw. Accept? (y/n):"
+	case awaitMove:
+		return "Input Your Move (ex. A-1, undo, draw, resign, abort):"
+	}
+	return ""
+}
+
+// input 標準入力を1行ずつ送るチャネル。Scannerを作り直すと読み込み済みの入力が失われるので、
+// 対局中も感想戦も、1つのgoroutineが読んだ行を受け取る。標準入力が閉じるとチャネルも閉じる
+func (r *Reversi) input() <-chan string {
+	r.inputOnce.Do(func() {
+		lines := make(chan string)
+		r.lines = lines
+		go func() {
+			defer close(lines)
+			stdin := bufio.NewScanner(os.Stdin)
+			for stdin.Scan() {
+				lines <- stdin.Text()
+			}
+		}()
+	})
+	return r.lines
+}
+
+// awaiting 今入力を待っている内容。相手からの申し込みへの返答を手より先に聞く
+func (r *Reversi) awaiting() awaiting {
+	r.RLock()
+	defer r.RUnlock()
+	switch {
+	case r.takebackRequested:
+		return awaitTakeback
+	case r.drawOffered:
+		return awaitDraw
+	case r.isColor == r.me.Character && !r.takebackWaiting:
+		return awaitMove
+	}
+	return awaitNone
+}
+
+// handleInput 入力された1行を、受け取った時点で待っている内容に振り分けて送る
+func (r *Reversi) handleInput(text string) error {
+	switch r.awaiting() {
+	case awaitTakeback:
+		return r.replyTakeback(strings.EqualFold(strings.TrimSpace(text), "y"))
+	case awaitDraw:
+		return r.replyDraw(strings.EqualFold(strings.TrimSpace(text), "y"))
+	case awaitMove:
+		return r.sendInput(text)
+	}
+	fmt.Println("Not your turn.")
+	return nil
+}
+
+func (r *Reversi) replyTakeback(accept bool) error {
+	r.Lock()
+	defer r.Unlock()
+	r.takebackRequested = false
+	return r.stream.Send(&pb.PlayRequest{
+		Action: &pb.PlayRequest_TakebackReply{
+			TakebackReply: &pb.TakebackReplyAction{
+				Accept: accept,
+			},
+		},
+	})
+}
+
+func (r *Reversi) replyDraw(accept bool) error {
+	r.Lock()
+	defer r.Unlock()
+	r.drawOffered = false
+	return r.stream.Send(&pb.PlayRequest{
+		Action: &pb.PlayRequest_DrawReply{
+			DrawReply: &pb.DrawReplyAction{
+				Accept: accept,
+			},
+		},
+	})
+}
+
+// sendInput 自分の手番で入力された手かコマンドを送る。手として読めなければ表示して入力し直させる
+func (r *Reversi) sendInput(text string) error {
+	if req := commandRequest(text); req != nil {
+		// 投了、引き分けの申し込み、中止。結果はサーバーからの通知で受け取る
+		r.Lock()
+		defer r.Unlock()
+		return r.stream.Send(req)
+	}
+	if strings.EqualFold(strings.TrimSpace(text), "undo") {
+		// 待ったを申し込む
+		r.Lock()
+		defer r.Unlock()
+		r.takebackWaiting = true
+		return r.stream.Send(&pb.PlayRequest{
+			Action: &pb.PlayRequest_Takeback{
+				Takeback: &pb.TakebackAction{},
+			},
+		})
+	}
+	x, y, err := parseInput(text)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+
+	// 手を打つ
+	r.Lock()
+	_, err = r.game.Move(x, y, r.me.Character)
+	if err != nil {
+		r.Unlock()
+		fmt.Println(err)
+		return nil
+	}
+	display(r.game, r.cfg.Renderer)
+
+	// サーバーに手を送る処理
+	go func() {
+		err := r.stream.Send(&pb.PlayRequest{
+			Action: &pb.PlayRequest_Move{
+				Move: &pb.MoveAction{
+					Move: &pb.Move{
+						X: x,
+						Y: y,
+					},
+				},
+			},
+		})
+		if err != nil {
+			// 通信が切れていれば、再接続時に受け取る盤面で手番が決まる
+			fmt.Println(err)
+		}
+
+		r.isColor = game.OpponentCharacter(r.me.Character)
+		r.Unlock()
+	}()
+	return nil
+}
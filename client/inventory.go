@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// rewardHistorySize 表示する報酬の履歴の件数
+const rewardHistorySize = 10
+
+// inventory 名前のプレイヤーが持っているカードと、最近付与された報酬を表示する
+func (r *Reversi) inventory(ctx context.Context, cli pb.RewardServiceClient) error {
+	if r.cfg.Name == "" {
+		return errors.New("-inventory requires -name")
+	}
+	inv, err := cli.GetInventory(ctx, &pb.GetInventoryRequest{Name: r.cfg.Name})
+	if status.Code(err) == codes.NotFound {
+		fmt.Printf("%v has not played yet.\n", r.cfg.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Inventory of %v\n", r.cfg.Name)
+	if len(inv.GetItems()) == 0 {
+		fmt.Println("  (empty)")
+		return nil
+	}
+	for _, item := range inv.GetItems() {
+		fmt.Printf("  %-24v x%v\n", item.GetCardId(), item.GetCount())
+	}
+
+	history, err := cli.ListRewardHistory(ctx, &pb.ListRewardHistoryRequest{
+		Name:  r.cfg.Name,
+		Limit: rewardHistorySize,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("\nRecent rewards")
+	for _, reward := range history.GetRewards() {
+		fmt.Printf("  %v  %-24v %v\n",
+			time.UnixMilli(reward.GetGrantedAtMs()).Format("2006-01-02 15:04"), reward.GetCardId(), reward.GetRoomId())
+	}
+	return nil
+}
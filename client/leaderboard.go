@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// leaderboardSize 表示する上位のプレイヤー数
+const leaderboardSize = 10
+
+// leaderboard 上位のプレイヤーを表示する。名前が設定されていれば、自分の順位も表示する
+func (r *Reversi) leaderboard(ctx context.Context, cli pb.LeaderboardServiceClient) error {
+	top, err := cli.Top(ctx, &pb.TopRequest{Limit: leaderboardSize})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Leaderboard (%v players)\n", top.GetTotal())
+	for _, p := range top.GetProfiles() {
+		printProfile(p)
+	}
+
+	if r.cfg.Name == "" {
+		return nil
+	}
+	rank, err := cli.GetRank(ctx, &pb.GetRankRequest{Name: r.cfg.Name})
+	if status.Code(err) == codes.NotFound {
+		fmt.Printf("\n%v has no rated games yet.\n", r.cfg.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	printProfile(rank.GetProfile())
+	return nil
+}
+
+// printProfile 順位、名前、レーティングと戦績を1行で表示する
+func printProfile(p *pb.Profile) {
+	rank := "-"
+	if p.GetRank() > 0 {
+		rank = fmt.Sprint(p.GetRank())
+	}
+	fmt.Printf("%4v  %-24v %6.0f ±%-4.0f  %vW %vL %vD\n",
+		rank, p.GetName(), p.GetRating(), 2*p.GetDeviation(), p.GetWins(), p.GetLosses(), p.GetDraws())
+}
+
+// opponent 対戦相手のプレイヤー
+func (r *Reversi) opponent() *game.Player {
+	if r.room.Host.ID == r.me.ID {
+		return r.room.Guest
+	}
+	return r.room.Host
+}
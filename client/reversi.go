@@ -1,11 +1,12 @@
 package client
 
 import (
-	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"io"
 	"kazuki.matsumoto/reversi/build"
 	"kazuki.matsumoto/reversi/game"
 	"kazuki.matsumoto/reversi/gen/pb"
@@ -16,22 +17,87 @@ import (
 	"time"
 )
 
+// Config クライアントの設定
+type Config struct {
+	AllowBot       bool             // 対戦相手が見つからない場合にAIと対戦する
+	TimeControl    game.TimeControl // 希望する持ち時間
+	AnyTimeControl bool             // 相手が希望する持ち時間でも良い
+	Color          game.Character   // 希望する色。Noneならどちらでも良い
+	Watch          string           // 空でなければ、マッチングせずにこの部屋を観戦する
+	Name           string           // プレイヤー名。空の場合はサーバーがゲスト名を付ける
+	Leaderboard    bool             // 対局せずにランキングを表示する
+	CreateRoom     bool             // マッチングせずに招待コード付きの部屋を作成する
+	Private        bool             // 作成する部屋を一覧に表示しない
+	NoSpectators   bool             // 作成する部屋の観戦を許可しない
+	InviteCode     string           // 空でなければ、マッチングせずにこの招待コードの部屋に参加する
+	ListRooms      bool             // 対局せずにゲストを待っている部屋の一覧を表示する
+	Inventory      bool             // 対局せずに、Nameのプレイヤーが持っているカードと報酬の履歴を表示する
+	Verify         bool             // 対局せずに、Nameのプレイヤーの報酬を明かされた種で確かめる
+	ClientSeed     string           // 空でなければ、マッチング後に報酬の抽選の種をこの種で入れ替える
+	Renderer       game.Renderer    // 盤面の描き方。nilならUnicodeRenderer
+	TUI            bool             // 全画面で対局する。カーソルキーかマウスで手を選ぶ
+	Credentials    string           // 名前ごとのプレイヤートークンを保存するファイル。空の場合は保存せず、登録した名前で参加し直せない
+}
+
 type Reversi struct {
 	sync.RWMutex
+	cfg      Config
 	started  bool
 	finished bool
 	isColor  game.Character //手番を表す
-	me       *game.Player
-	room     *game.Room
-	game     *game.Game
+	// 待ったの状態。相手から申し込まれて返答待ち(takebackRequested)か、自分が申し込んで返答待ち(takebackWaiting)
+	takebackRequested bool
+	takebackWaiting   bool
+	drawOffered       bool // 相手から引き分けを申し込まれて返答待ち
+	me                *game.Player
+	token             string                    // GameServiceでプレイヤーを確かめるトークン。通信が切れた場合も同じトークンで元の席に戻る
+	stream            pb.GameService_PlayClient // 再接続すると差し替わるので、ロックを取って参照する
+	room              *game.Room
+	game              *game.Game
+	seedHash          string // 抽選に使っている種のハッシュ。まだ種を入れ替えていなければ空
+	nextSeedHash      string // 種を入れ替えると使い始める種のハッシュ。ゲストの場合は空
+	inputOnce         sync.Once
+	lines             <-chan string // 標準入力から読んだ行。inputで1度だけ読み始める
 }
 
-func NewReversi() *Reversi {
+func NewReversi(cfg Config) *Reversi {
+	if cfg.Renderer == nil {
+		cfg.Renderer = game.UnicodeRenderer{}
+	}
 	return &Reversi{
+		cfg:     cfg,
 		isColor: game.Black,
 	}
 }
 
+// ParseColor コマンドライン引数から希望する色を作る
+func ParseColor(color string) (game.Character, error) {
+	switch strings.ToLower(color) {
+	case "", "any":
+		return game.None, nil
+	case "black":
+		return game.Black, nil
+	case "white":
+		return game.White, nil
+	}
+	return game.None, fmt.Errorf("unknown color %q: any, black, white", color)
+}
+
+// ParseRenderer コマンドライン引数から盤面の描き方を作る
+func ParseRenderer(name string, opts game.RenderOptions) (game.Renderer, error) {
+	switch strings.ToLower(name) {
+	case "", "unicode":
+		return game.UnicodeRenderer{RenderOptions: opts}, nil
+	case "ascii":
+		return game.ASCIIRenderer{RenderOptions: opts}, nil
+	case "ansi":
+		return game.ANSIRenderer{RenderOptions: opts}, nil
+	case "compact":
+		return game.CompactRenderer{RenderOptions: opts}, nil
+	}
+	return nil, fmt.Errorf("unknown board %q: unicode, ascii, ansi, compact", name)
+}
+
 func (r *Reversi) Run() int {
 	if err := r.run(); err != nil {
 		fmt.Println(err)
@@ -50,29 +116,100 @@ func (r *Reversi) run() error {
 	}
 	defer conn.Close()
 
+	// ランキングや部屋の一覧、所持品を表示するだけなら対局しない
+	if r.cfg.Leaderboard {
+		return r.leaderboard(ctx, pb.NewLeaderboardServiceClient(conn))
+	}
+	if r.cfg.ListRooms {
+		return r.listRooms(ctx, pb.NewMatchingServiceClient(conn))
+	}
+	if r.cfg.Inventory {
+		return r.inventory(ctx, pb.NewRewardServiceClient(conn))
+	}
+	if r.cfg.Verify {
+		return r.verify(ctx, pb.NewRewardServiceClient(conn))
+	}
+
+	// 観戦の場合はマッチングせずに部屋の通知を受け取る
+	if r.cfg.Watch != "" {
+		return r.watch(ctx, pb.NewGameServiceClient(conn))
+	}
+
+	// 全画面で対局できなければ、マッチングする前にやめる
+	if r.cfg.TUI {
+		if err := checkTerminal(); err != nil {
+			return err
+		}
+	}
+
 	// マッチング問い合わせ
 	err = r.matching(ctx, pb.NewMatchingServiceClient(conn))
 	if err != nil {
 		return err
 	}
+	// 種の入れ替えにはマッチングで受け取ったトークンを使う
+	// まだ種を入れ替えたことがなければ、自分の種を指定していなくても入れ替え、この対局から抽選できるようにする
+	if r.cfg.ClientSeed != "" || r.nextSeedHash != "" && r.seedHash == "" {
+		if err := r.rotateSeed(ctx, pb.NewRewardServiceClient(conn)); err != nil {
+			return err
+		}
+	}
 
 	// マッチングできたので盤面作成
-	r.game = game.NewGame(r.me.Character)
+	// 終了時にresetされても感想戦で使えるように、参照を残しておく
+	g := game.NewGame(r.me.Character)
+	r.game = g
+
+	// 全画面の場合は、終局後の感想戦も全画面の中で行う
+	if r.cfg.TUI {
+		return r.playTUI(ctx, pb.NewGameServiceClient(conn))
+	}
 
 	// 双方向ストリーミングでゲーム処理
-	return r.play(ctx, pb.NewGameServiceClient(conn))
+	err = r.play(ctx, pb.NewGameServiceClient(conn))
+	if err != nil {
+		return err
+	}
+
+	// 終局後は手元で棋譜を振り返る
+	review(g, r.cfg.Renderer, r.input())
+	return nil
 }
 
 func (r *Reversi) matching(ctx context.Context, cli pb.MatchingServiceClient) error {
+	// 招待コードがあれば、待っているホストの部屋にすぐ参加できる
+	if r.cfg.InviteCode != "" {
+		return r.joinByCode(ctx, cli)
+	}
+	if r.cfg.CreateRoom {
+		return r.createRoom(ctx, cli)
+	}
+
 	// マッチングリクエスト
-	stream, err := cli.JoinRoom(ctx, &pb.JoinRoomRequest{})
+	stream, err := cli.JoinRoom(ctx, &pb.JoinRoomRequest{
+		AllowBot:       r.cfg.AllowBot,
+		TimeControl:    build.PBTimeControl(r.cfg.TimeControl),
+		AnyTimeControl: r.cfg.AnyTimeControl,
+		Color:          build.PBCharacter(r.cfg.Color),
+		Name:           r.cfg.Name,
+		PlayerToken:    r.playerToken(),
+	})
 	if err != nil {
 		return err
 	}
 	defer stream.CloseSend()
 
 	fmt.Println("Requested matching...")
+	return r.waitMatching(stream)
+}
+
+// matchingStream マッチングが成立するまでレスポンスを受け取るstream。JoinRoomとCreateRoomで共通
+type matchingStream interface {
+	Recv() (*pb.JoinRoomResponse, error)
+}
 
+// waitMatching マッチングが成立するまで待つ
+func (r *Reversi) waitMatching(stream matchingStream) error {
 	// ストリーミングでレスポンスを受け取る
 	for {
 		resp, err := stream.Recv()
@@ -81,37 +218,75 @@ func (r *Reversi) matching(ctx context.Context, cli pb.MatchingServiceClient) er
 		}
 		// マッチング成立
 		if resp.GetStatus() == pb.JoinRoomResponse_MATCHED {
-			r.room = build.Room(resp.GetRoom())
-			r.me = build.Player(resp.GetMe())
-			fmt.Printf("Matched room_id=%\n", resp.GetRoom().GetId())
+			r.matched(resp)
 			return nil
 		} else if resp.GetStatus() == pb.JoinRoomResponse_WAITING {
+			if code := resp.GetRoom().GetInviteCode(); code != "" {
+				fmt.Printf("Room created. Invite code: %v\n", code)
+			}
 			fmt.Println("Waiting matching...")
 		}
 	}
 }
 
+// matched マッチングした部屋と自分の席を覚えておく
+func (r *Reversi) matched(resp *pb.JoinRoomResponse) {
+	r.token = resp.GetSessionToken()
+	r.room = build.Room(resp.GetRoom())
+	r.me = build.Player(resp.GetMe())
+	fmt.Printf("Matched room_id=%v\n", resp.GetRoom().GetId())
+	if token := resp.GetPlayerToken(); token != "" {
+		r.savePlayerToken(r.me.Name, token)
+	}
+	// 他のプレイヤーが登録した名前は使えず、ゲストとして参加する
+	if r.cfg.Name != "" && r.me.Name != r.cfg.Name {
+		fmt.Printf("Name %q is registered by another player. You joined as %v\n", r.cfg.Name, r.me.Name)
+	}
+	if resp.GetRoom().GetGuest().GetBot() {
+		fmt.Println("Your opponent is AI")
+	} else {
+		fmt.Printf("You are %v. Your opponent is %v\n", r.me.Name, r.opponent().Name)
+	}
+	fmt.Printf("You play %v\n", build.PBCharacter(r.me.Character))
+	if tc := r.room.TimeControl; tc.Enabled() {
+		fmt.Printf("Time control: %v\n", timeControlString(tc))
+	}
+	// 勝った時の抽選に使う種のハッシュ。種を明かした後に-verifyで照らし合わせる
+	r.seedHash, r.nextSeedHash = resp.GetSeedHash(), resp.GetNextSeedHash()
+	if r.seedHash != "" {
+		fmt.Printf("Reward seed hash: %v\n", r.seedHash)
+	}
+}
+
 func (r *Reversi) play(ctx context.Context, cli pb.GameServiceClient) error {
 	c, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	// 双方向ストリーミングを開始する
-	stream, err := cli.Play(c)
+	stream, err := cli.Play(r.authorized(c))
 	if err != nil {
 		return err
 	}
-	defer stream.CloseSend()
+	r.Lock()
+	r.stream = stream
+	r.Unlock()
+	defer func() {
+		// 再接続していれば差し替わったstreamを閉じる
+		r.RLock()
+		r.stream.CloseSend()
+		r.RUnlock()
+	}()
 
 	go func() {
 		// 自分の手を送信
-		err := r.send(c, stream)
+		err := r.send(c)
 		if err != nil {
 			cancel()
 		}
 	}()
 
 	// 相手からの手を受信
-	err = r.receive(c, stream)
+	err = r.receive(c, cli)
 	if err != nil {
 		cancel()
 		return err
@@ -120,6 +295,11 @@ func (r *Reversi) play(ctx context.Context, cli pb.GameServiceClient) error {
 	return nil
 }
 
+// authorized マッチング時に受け取ったトークンを付けたcontext。サーバーはリクエストの内容ではなく、トークンからプレイヤーと部屋を決める
+func (r *Reversi) authorized(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+r.token)
+}
+
 func (r *Reversi) reset() {
 	r.Lock()
 	defer r.Unlock()
@@ -131,7 +311,9 @@ func (r *Reversi) reset() {
 	r.game = nil
 }
 
-func (r *Reversi) send(ctx context.Context, stream pb.GameService_PlayClient) error {
+func (r *Reversi) send(ctx context.Context) error {
+	lines := r.input()
+	prompted := awaitNone
 	for {
 		// sendを送る時、recv側のfinishedやstartedが変更しないようにする
 		r.RLock()
@@ -144,9 +326,7 @@ func (r *Reversi) send(ctx context.Context, stream pb.GameService_PlayClient) er
 			return nil
 			// 未開始なので、開始リクエストを送る
 		} else if !r.started {
-			err := stream.Send(&pb.PlayRequest{
-				RoomId: r.room.ID,
-				Player: build.PBPlayer(r.me),
+			err := r.stream.Send(&pb.PlayRequest{
 				Action: &pb.PlayRequest_Start{
 					Start: &pb.StartAction{},
 				},
@@ -174,55 +354,27 @@ func (r *Reversi) send(ctx context.Context, stream pb.GameService_PlayClient) er
 			// else以下になったら対戦中
 			r.RUnlock()
 
-			// 自分の手番でない場合はスキップ
-			if r.isColor != r.me.Character {
-				continue
-			}
-
-			// 手の入力を待機
-			fmt.Print("Input Your Move (ex. A-1):")
-			stdin := bufio.NewScanner(os.Stdin)
-			stdin.Scan()
-
-			// 入力された手を解析
-			text := stdin.Text()
-			x, y, err := parseInput(text)
-			if err != nil {
-				fmt.Println(err)
-				continue
+			// 返答や手を待っている内容が変わった時だけ促す
+			p := r.awaiting()
+			if p != prompted {
+				fmt.Print(p.message())
+				prompted = p
 			}
-
-			// 手を打つ
-			r.Lock()
-			_, err = r.game.Move(x, y, r.me.Character)
-			if err != nil {
-				r.Unlock()
-				fmt.Println(err)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(inputInterval):
+				// 相手の手や申し込みで待っている内容が変わるので、確かめ直す
 				continue
-			}
-
-			// サーバーに手を送る処理
-			go func() {
-				err = stream.Send(&pb.PlayRequest{
-					RoomId: r.room.ID,
-					Player: build.PBPlayer(r.me),
-					Action: &pb.PlayRequest_Move{
-						Move: &pb.MoveAction{
-							Move: &pb.Move{
-								X: x,
-								Y: y,
-							},
-						},
-					},
-				})
-				if err != nil {
-					r.Unlock()
-					fmt.Println(err)
+			case text, ok := <-lines:
+				if !ok {
+					return io.EOF
 				}
-
-				r.isColor = game.OpponentCharacter(r.me.Character)
-				r.Unlock()
-			}()
+				prompted = awaitNone
+				if err := r.handleInput(text); err != nil {
+					return err
+				}
+			}
 		}
 
 		select {
@@ -234,11 +386,24 @@ func (r *Reversi) send(ctx context.Context, stream pb.GameService_PlayClient) er
 	}
 }
 
-func (r *Reversi) receive(ctx context.Context, stream pb.GameService_PlayClient) error {
+func (r *Reversi) receive(ctx context.Context, cli pb.GameServiceClient) error {
 	for {
+		r.RLock()
+		stream := r.stream
+		r.RUnlock()
+
 		res, err := stream.Recv()
 		if err != nil {
-			return err
+			// キャンセルされた場合は再接続しない
+			if ctx.Err() != nil {
+				return err
+			}
+			// 通信が切れたので、同じ席に戻れるよう再接続する
+			fmt.Println("\nConnection lost. Reconnecting...")
+			if rerr := r.resume(ctx, cli); rerr != nil {
+				return err
+			}
+			continue
 		}
 
 		r.Lock()
@@ -249,7 +414,7 @@ func (r *Reversi) receive(ctx context.Context, stream pb.GameService_PlayClient)
 		case *pb.PlayResponse_Ready:
 			// 開始
 			r.started = true
-			r.game.Display()
+			display(r.game, r.cfg.Renderer)
 		case *pb.PlayResponse_Move:
 			// 手を打たれた
 			character := build.Character(res.GetMove().GetPlayer().GetCharacter())
@@ -260,26 +425,109 @@ func (r *Reversi) receive(ctx context.Context, stream pb.GameService_PlayClient)
 				if err != nil {
 					return err
 				}
+				display(r.game, r.cfg.Renderer)
+				// 自分が置ける場所がない場合は、続けて送られてくるPassEventで手番を決める
+				if r.game.MustPass() {
+					break
+				}
 				// 相手の手番が終わったので自分の手番に変更
 				// 送信側でも色を変えてるが、プロセスが別れている==メモリも別れているので、こちらも変更の必要がある。
 				r.isColor = r.me.Character
+				printClocks(res.GetMove().GetClocks())
+				fmt.Print("Input Your Move (ex. A-1):")
+			}
+		case *pb.PlayResponse_Clock:
+			// 自分の時計が進んでいる間、残り時間が少なくなったら知らせる
+			clocks := res.GetClock().GetClocks()
+			if build.Character(clocks.GetRunning()) == r.me.Character {
+				warnClock(clocks, r.me.Character)
+			}
+		case *pb.PlayResponse_Pass:
+			// 置ける場所がなかったのでパスされた
+			character := build.Character(res.GetPass().GetPlayer().GetCharacter())
+			err = r.game.Pass(character)
+			if err != nil {
+				r.Unlock()
+				return err
+			}
+			r.isColor = r.game.Turn()
+			if character == r.me.Character {
+				fmt.Println("\nYou have no move. Pass.")
+			} else {
+				fmt.Println("\nOpponent has no move. Pass.")
 				fmt.Print("Input Your Move (ex. A-1):")
 			}
+		case *pb.PlayResponse_Snapshot:
+			// 再接続したので、サーバーの棋譜で手元の盤面を作り直す
+			snapshot := res.GetSnapshot()
+			err = r.restore(snapshot.GetMoves())
+			if err != nil {
+				r.Unlock()
+				return err
+			}
+			r.started = snapshot.GetStarted()
+			r.isColor = r.game.Turn()
+			r.takebackRequested = false
+			r.takebackWaiting = false
+			fmt.Println("\nReconnected.")
+			display(r.game, r.cfg.Renderer)
+			printClocks(snapshot.GetClocks())
+			if r.started && r.isColor == r.me.Character {
+				fmt.Print("Input Your Move (ex. A-1, undo):")
+			}
+		case *pb.PlayResponse_TakebackRequested:
+			// 待ったを申し込まれた。返答は送信側で入力を受け付ける
+			if build.Character(res.GetTakebackRequested().GetPlayer().GetCharacter()) != r.me.Character {
+				fmt.Println("\nOpponent requested a takeback.")
+				r.takebackRequested = true
+			} else {
+				fmt.Println("\nWaiting for opponent's answer...")
+			}
+		case *pb.PlayResponse_Takeback:
+			r.takebackRequested = false
+			r.takebackWaiting = false
+			takeback := res.GetTakeback()
+			if !takeback.GetAccepted() {
+				fmt.Println("\nTakeback declined.")
+				break
+			}
+			// サーバーと同じ手数まで戻す
+			err = r.game.Jump(int(takeback.GetPly()))
+			if err != nil {
+				r.Unlock()
+				return err
+			}
+			r.isColor = r.game.Turn()
+			fmt.Println("\nTakeback accepted.")
+			display(r.game, r.cfg.Renderer)
+			if r.isColor == r.me.Character {
+				fmt.Print("Input Your Move (ex. A-1, undo):")
+			}
+		case *pb.PlayResponse_DrawOffered:
+			// 引き分けを申し込まれた。返答は送信側で入力を受け付ける
+			if build.Character(res.GetDrawOffered().GetPlayer().GetCharacter()) != r.me.Character {
+				fmt.Println("\nOpponent offered a draw.")
+				r.drawOffered = true
+			} else {
+				fmt.Println("\nDraw offered. Opponent can accept until their next move.")
+			}
+		case *pb.PlayResponse_DrawDeclined:
+			r.drawOffered = false
+			fmt.Println("\nDraw declined.")
+		case *pb.PlayResponse_Error:
+			// 待ったが受け付けられなかった場合に備えて、返答待ちを解除する
+			r.takebackWaiting = false
+			// サーバーにリクエストを拒否された
+			fmt.Printf("\nrejected by server: %v (%v)\n", res.GetError().GetMessage(), res.GetError().GetCode())
 		case *pb.PlayResponse_Finished:
 			r.finished = true
 
 			// 勝敗表示
-			winner := build.Character(res.GetFinished().Winner)
 			fmt.Println("")
-			if winner == game.None {
-				fmt.Println("Draw!")
-			} else if winner == r.me.Character {
-				fmt.Println("You Win!")
-				s := game.DrawGenerics() // 抽選
-				fmt.Println(s)
-			} else {
-				fmt.Println("You Lose!")
+			for _, line := range resultLines(res.GetFinished(), r.me) {
+				fmt.Println(line)
 			}
+			fmt.Printf("Transcript: %v\n", r.game.Transcript())
 			r.Unlock()
 			// ループ終了するのでreturn
 			return nil
@@ -295,6 +543,59 @@ func (r *Reversi) receive(ctx context.Context, stream pb.GameService_PlayClient)
 	}
 }
 
+const (
+	resumeRetry    = 5
+	resumeInterval = 2 * time.Second
+)
+
+// resume 新しいstreamを開き、マッチング時のトークンで元の席に戻る。盤面はSnapshotEventで受け取る
+func (r *Reversi) resume(ctx context.Context, cli pb.GameServiceClient) error {
+	var err error
+	for i := 0; i < resumeRetry; i++ {
+		time.Sleep(resumeInterval)
+
+		var stream pb.GameService_PlayClient
+		stream, err = cli.Play(r.authorized(ctx))
+		if err != nil {
+			continue
+		}
+		r.Lock()
+		err = stream.Send(&pb.PlayRequest{
+			Action: &pb.PlayRequest_Resume{
+				Resume: &pb.ResumeAction{},
+			},
+		})
+		if err == nil {
+			r.stream = stream
+		}
+		r.Unlock()
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// restore 初期盤面から棋譜を打ち直す。感想戦で参照しているので、Gameは作り直さずに使い回す。ロックを取った状態で呼ぶ
+func (r *Reversi) restore(moves []*pb.Ply) error {
+	if err := r.game.Jump(0); err != nil {
+		return err
+	}
+	for _, m := range moves {
+		ply := build.Ply(m)
+		var err error
+		if ply.Pass {
+			err = r.game.Pass(ply.Character)
+		} else {
+			_, err = r.game.Move(ply.X, ply.Y, ply.Character)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // `A-2`の形式で入力された手を(x, y)=(1, 2)の形式に変換する
 func parseInput(txt string) (int32, int32, error) {
 	ss := strings.Split(txt, "-")
@@ -322,3 +623,55 @@ func parseInput(txt string) (int32, int32, error) {
 
 	return x, int32(y), nil
 }
+
+// review 終局後に手元の棋譜で盤面を行き来する。linesは対局中と同じ標準入力の行
+func review(g *game.Game, renderer game.Renderer, lines <-chan string) {
+	fmt.Println("Review the game? commands: b(back), f(forward), j N(jump to ply N), q(quit)")
+	for {
+		fmt.Printf("[%d/%d] > ", g.Cursor(), g.Len())
+		text, ok := <-lines
+		if !ok {
+			return
+		}
+
+		var err error
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "b":
+			err = g.Undo()
+		case "f":
+			err = g.Redo()
+		case "j":
+			if len(fields) != 2 {
+				fmt.Println("usage: j N")
+				continue
+			}
+			n, convErr := strconv.Atoi(fields[1])
+			if convErr != nil {
+				fmt.Println("usage: j N")
+				continue
+			}
+			err = g.Jump(n)
+		case "q":
+			return
+		default:
+			fmt.Println("unknown command")
+			continue
+		}
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		display(g, renderer)
+	}
+}
+
+// display 盤面を標準出力に描く
+func display(g *game.Game, renderer game.Renderer) {
+	if err := renderer.Render(os.Stdout, g); err != nil {
+		fmt.Println(err)
+	}
+}
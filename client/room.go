@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"kazuki.matsumoto/reversi/build"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// createRoom 招待コード付きの部屋を作成し、ゲストが参加するまで待つ
+func (r *Reversi) createRoom(ctx context.Context, cli pb.MatchingServiceClient) error {
+	visibility := pb.Visibility_PUBLIC
+	if r.cfg.Private {
+		visibility = pb.Visibility_PRIVATE
+	}
+	stream, err := cli.CreateRoom(ctx, &pb.CreateRoomRequest{
+		Settings: &pb.RoomSettings{
+			Visibility:      visibility,
+			TimeControl:     build.PBTimeControl(r.cfg.TimeControl),
+			AllowSpectators: !r.cfg.NoSpectators,
+		},
+		Name:        r.cfg.Name,
+		Color:       build.PBCharacter(r.cfg.Color),
+		PlayerToken: r.playerToken(),
+	})
+	if err != nil {
+		return err
+	}
+	defer stream.CloseSend()
+
+	fmt.Println("Creating room...")
+	return r.waitMatching(stream)
+}
+
+// joinByCode 招待コードの部屋に参加する
+func (r *Reversi) joinByCode(ctx context.Context, cli pb.MatchingServiceClient) error {
+	resp, err := cli.JoinRoomByCode(ctx, &pb.JoinRoomByCodeRequest{
+		InviteCode:  r.cfg.InviteCode,
+		Name:        r.cfg.Name,
+		PlayerToken: r.playerToken(),
+	})
+	if err != nil {
+		return err
+	}
+	r.matched(resp)
+	return nil
+}
+
+// listRooms ゲストを待っている公開の部屋を表示する
+func (r *Reversi) listRooms(ctx context.Context, cli pb.MatchingServiceClient) error {
+	resp, err := cli.ListRooms(ctx, &pb.ListRoomsRequest{})
+	if err != nil {
+		return err
+	}
+	if len(resp.GetRooms()) == 0 {
+		fmt.Println("No rooms are waiting. Create one with -create")
+		return nil
+	}
+
+	fmt.Println("Rooms waiting for a guest")
+	for _, room := range resp.GetRooms() {
+		tc := "no clock"
+		if t := build.TimeControl(room.GetTimeControl()); t.Enabled() {
+			tc = timeControlString(t)
+		}
+		watch := ""
+		if !room.GetAllowSpectators() {
+			watch = " (no spectators)"
+		}
+		fmt.Printf("  %v  host=%-24v %v%v\n", room.GetInviteCode(), room.GetHost().GetName(), tc, watch)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+//go:build linux || darwin
+
+package client
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// terminal 全画面で表示している間の端末。rawモードにして1キーずつ読み、閉じると元に戻す
+type terminal struct {
+	fd    int
+	saved unix.Termios
+	// in キーを読む端末。標準入力とは別に開くので、閉じると読み込み中のReadが戻る
+	in *os.File
+}
+
+// checkTerminal 標準入力が全画面で表示できる端末かを確かめる。マッチングする前に確かめ、相手を待たせないようにする
+func checkTerminal() error {
+	if _, err := unix.IoctlGetTermios(int(os.Stdin.Fd()), ioctlGetTermios); err != nil {
+		return errNotTerminal
+	}
+	return nil
+}
+
+// openTerminal 標準入力の端末をrawモードにし、代替画面に切り替えてマウスのクリックを受け取る
+func openTerminal() (*terminal, error) {
+	fd := int(os.Stdin.Fd())
+	t, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, errNotTerminal
+	}
+	saved := *t
+	// 標準入力のReadは閉じても戻らないので、同じ端末を別に開いて読む
+	in, err := os.Open("/dev/tty")
+	if err != nil {
+		return nil, err
+	}
+
+	// cfmakerawと同じく、入力を加工せずに1バイトずつ受け取る。Ctrl-Cもシグナルにせずキーとして受け取る
+	t.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	t.Oflag &^= unix.OPOST
+	t.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	t.Cflag &^= unix.CSIZE | unix.PARENB
+	t.Cflag |= unix.CS8
+	t.Cc[unix.VMIN] = 1
+	t.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, t); err != nil {
+		in.Close()
+		return nil, err
+	}
+
+	// 代替画面、カーソルを隠す、マウスのクリックをSGRの形式で受け取る
+	os.Stdout.WriteString("\x1b[?1049h\x1b[?25l\x1b[?1000h\x1b[?1006h")
+	return &terminal{fd: fd, saved: saved, in: in}, nil
+}
+
+// input キーを読む端末
+func (t *terminal) input() io.Reader {
+	return t.in
+}
+
+// close 開いた時と逆の順で端末を元に戻す
+func (t *terminal) close() error {
+	t.in.Close()
+	os.Stdout.WriteString("\x1b[?1006l\x1b[?1000l\x1b[?25h\x1b[?1049l")
+	return unix.IoctlSetTermios(t.fd, ioctlSetTermios, &t.saved)
+}
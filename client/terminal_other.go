@@ -0,0 +1,24 @@
+//go:build !linux && !darwin
+
+package client
+
+import "io"
+
+// terminal 全画面表示はLinuxとmacOSの端末だけで使える
+type terminal struct{}
+
+func checkTerminal() error {
+	return errTUIUnsupported
+}
+
+func openTerminal() (*terminal, error) {
+	return nil, errTUIUnsupported
+}
+
+func (t *terminal) close() error {
+	return nil
+}
+
+func (t *terminal) input() io.Reader {
+	return nil
+}
@@ -0,0 +1,474 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"kazuki.matsumoto/reversi/build"
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+var (
+	// errNotTerminal 標準入力が端末ではないので全画面で表示できない
+	errNotTerminal = errors.New("tui requires a terminal")
+	// errTUIUnsupported 全画面表示に対応していないOS
+	errTUIUnsupported = errors.New("tui is not supported on this platform")
+)
+
+const (
+	// tuiFrame 盤面を描き直す間隔。石を返す動きと時計を進めるのに使う
+	tuiFrame = 50 * time.Millisecond
+	// flipDuration 返した石の動きを見せる時間
+	flipDuration = 240 * time.Millisecond
+)
+
+// prompt 全画面表示でy/nの返答を待っている質問
+type prompt int
+
+const (
+	promptNone     prompt = iota
+	promptTakeback        // 相手からの待ったの申し込み
+	promptDraw            // 相手からの引き分けの申し込み
+	promptResign          // 自分の投了の確認
+)
+
+// tui 全画面表示の対局の状態。イベントとキーは1つのgoroutineで処理するので、ロックは取らない
+type tui struct {
+	r        *Reversi
+	cursorX  int32
+	cursorY  int32
+	started  bool
+	finished bool
+	pending  bool // 手を送り、サーバーから届くのを待っている
+	waiting  bool // 待ったや引き分けを申し込み、相手の返答を待っている
+	passed   bool // 相手が直前にパスした
+	prompt   prompt
+	status   string // 最後のお知らせ
+	alert    bool   // statusが拒否やエラーのお知らせ
+	clocks   *pb.Clocks
+	clocksAt time.Time              // clocksを受け取った時刻。進んでいる時計の残り時間を手元で減らす
+	flips    map[[2]int32]time.Time // 返した石と返した時刻
+	from     game.Character         // 返した石の元の色
+	result   []string               // 終局後の結果。全画面を閉じた後にも表示する
+	record   string                 // 終局時の棋譜。感想戦で盤面を戻しても変わらない
+}
+
+// tuiEvent サーバーから受け取ったイベント。通信が切れた場合はlostがtrue、再接続できなければerrが入る
+type tuiEvent struct {
+	res  *pb.PlayResponse
+	lost bool
+	err  error
+}
+
+// playTUI 全画面で対局する。盤面はその場で描き直し、カーソルキーかマウスで手を選ぶ
+func (r *Reversi) playTUI(ctx context.Context, cli pb.GameServiceClient) error {
+	term, err := openTerminal()
+	if err != nil {
+		return err
+	}
+	t := &tui{
+		r:       r,
+		cursorX: 4,
+		cursorY: 4,
+		flips:   make(map[[2]int32]time.Time),
+	}
+	err = t.run(ctx, cli, term.input())
+	if cerr := term.close(); err == nil {
+		err = cerr
+	}
+
+	// 全画面を閉じた後も結果が残るよう、通常の画面に書き出す
+	for _, line := range t.result {
+		fmt.Println(line)
+	}
+	if t.finished {
+		fmt.Printf("Transcript: %v\n", t.record)
+	}
+	return err
+}
+
+// run inからキーを読みながら対局する。inは戻った後に閉じてreadKeysを止める
+func (t *tui) run(ctx context.Context, cli pb.GameServiceClient, in io.Reader) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := cli.Play(t.r.authorized(ctx))
+	if err != nil {
+		return err
+	}
+	t.r.Lock()
+	t.r.stream = stream
+	t.r.Unlock()
+	defer func() {
+		// 再接続していれば差し替わったstreamを閉じる
+		t.r.RLock()
+		t.r.stream.CloseSend()
+		t.r.RUnlock()
+	}()
+
+	if err := t.send(&pb.PlayRequest{Action: &pb.PlayRequest_Start{Start: &pb.StartAction{}}}); err != nil {
+		return err
+	}
+
+	events := make(chan tuiEvent)
+	go t.receive(ctx, cli, events)
+	keys := make(chan key)
+	go readKeys(ctx, in, keys)
+	ticker := time.NewTicker(tuiFrame)
+	defer ticker.Stop()
+
+	t.draw()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e := <-events:
+			if e.err != nil {
+				return e.err
+			}
+			if e.lost {
+				t.notify("Connection lost. Reconnecting...", true)
+				break
+			}
+			if err := t.handle(e.res); err != nil {
+				return err
+			}
+		case k, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			quit, err := t.press(k)
+			if quit || err != nil {
+				return err
+			}
+		case <-ticker.C:
+		}
+		t.draw()
+	}
+}
+
+// receive サーバーからのイベントをeventsに送る。通信が切れたら同じ席に戻れるよう再接続する
+func (t *tui) receive(ctx context.Context, cli pb.GameServiceClient, events chan<- tuiEvent) {
+	emit := func(e tuiEvent) bool {
+		select {
+		case events <- e:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	for {
+		t.r.RLock()
+		stream := t.r.stream
+		t.r.RUnlock()
+
+		res, err := stream.Recv()
+		if err != nil {
+			// キャンセルされた場合は再接続しない
+			if ctx.Err() != nil {
+				return
+			}
+			if !emit(tuiEvent{lost: true}) {
+				return
+			}
+			if rerr := t.r.resume(ctx, cli); rerr != nil {
+				emit(tuiEvent{err: rerr})
+				return
+			}
+			continue
+		}
+		if !emit(tuiEvent{res: res}) {
+			return
+		}
+	}
+}
+
+// send サーバーにリクエストを送る。再接続で差し替わるstreamはロックを取って参照する
+func (t *tui) send(req *pb.PlayRequest) error {
+	t.r.Lock()
+	defer t.r.Unlock()
+	return t.r.stream.Send(req)
+}
+
+func (t *tui) notify(status string, alert bool) {
+	t.status = status
+	t.alert = alert
+}
+
+// handle サーバーからのイベントを盤面と表示に反映する
+func (t *tui) handle(res *pb.PlayResponse) error {
+	g, me := t.r.game, t.r.me
+	switch res.GetEvent().(type) {
+	case *pb.PlayResponse_Waiting:
+		t.notify("Waiting until opponent player ready", false)
+	case *pb.PlayResponse_Ready:
+		t.started = true
+		t.notify("Game started!", false)
+	case *pb.PlayResponse_Move:
+		move := res.GetMove()
+		character := build.Character(move.GetPlayer().GetCharacter())
+		x, y := move.GetMove().GetX(), move.GetMove().GetY()
+		before := g.Board.Clone()
+		if _, err := g.Move(x, y, character); err != nil {
+			return err
+		}
+		t.animate(before, character)
+		t.setClocks(move.GetClocks())
+		t.passed = false
+		// 手が打たれると、返答がなかった申し込みは取り下げられる
+		t.waiting = false
+		if character == me.Character {
+			t.pending = false
+			// 手を打つと、申し込まれていた引き分けは断ったことになる
+			if t.prompt == promptDraw {
+				t.prompt = promptNone
+			}
+		}
+		t.notify(fmt.Sprintf("%v: %v", build.PBCharacter(character), game.PlyNotation(game.Ply{X: x, Y: y})), false)
+	case *pb.PlayResponse_Clock:
+		t.setClocks(res.GetClock().GetClocks())
+	case *pb.PlayResponse_Pass:
+		character := build.Character(res.GetPass().GetPlayer().GetCharacter())
+		if err := g.Pass(character); err != nil {
+			return err
+		}
+		if character == me.Character {
+			t.notify("You have no move. Pass.", false)
+		} else {
+			t.passed = true
+			t.notify("Opponent has no move. Pass.", false)
+		}
+	case *pb.PlayResponse_Snapshot:
+		// 再接続したので、サーバーの棋譜で手元の盤面を作り直す
+		snapshot := res.GetSnapshot()
+		if err := t.r.restore(snapshot.GetMoves()); err != nil {
+			return err
+		}
+		t.started = snapshot.GetStarted()
+		t.pending = false
+		t.waiting = false
+		t.prompt = promptNone
+		t.setClocks(snapshot.GetClocks())
+		t.notify("Reconnected.", false)
+	case *pb.PlayResponse_TakebackRequested:
+		if build.Character(res.GetTakebackRequested().GetPlayer().GetCharacter()) != me.Character {
+			t.prompt = promptTakeback
+		} else {
+			t.waiting = true
+			t.notify("Waiting for opponent's answer...", false)
+		}
+	case *pb.PlayResponse_Takeback:
+		t.waiting = false
+		takeback := res.GetTakeback()
+		if !takeback.GetAccepted() {
+			t.notify("Takeback declined.", false)
+			break
+		}
+		// サーバーと同じ手数まで戻す
+		if err := g.Jump(int(takeback.GetPly())); err != nil {
+			return err
+		}
+		t.flips = make(map[[2]int32]time.Time)
+		t.notify("Takeback accepted.", false)
+	case *pb.PlayResponse_DrawOffered:
+		if build.Character(res.GetDrawOffered().GetPlayer().GetCharacter()) != me.Character {
+			t.prompt = promptDraw
+		} else {
+			t.waiting = true
+			t.notify("Draw offered. Opponent can accept until their next move.", false)
+		}
+	case *pb.PlayResponse_DrawDeclined:
+		t.waiting = false
+		t.notify("Draw declined.", false)
+	case *pb.PlayResponse_Error:
+		// 手や申し込みが受け付けられなかったので、返答待ちを解除する
+		t.pending = false
+		t.waiting = false
+		t.notify(fmt.Sprintf("rejected by server: %v (%v)", res.GetError().GetMessage(), res.GetError().GetCode()), true)
+	case *pb.PlayResponse_Finished:
+		t.finished = true
+		t.prompt = promptNone
+		t.result = resultLines(res.GetFinished(), me)
+		t.record = g.Transcript()
+		t.notify("Game finished.", false)
+	}
+	return nil
+}
+
+// setClocks 受け取った残り時間を覚える。持ち時間がない部屋ではnil
+func (t *tui) setClocks(c *pb.Clocks) {
+	if c == nil {
+		return
+	}
+	t.clocks = c
+	t.clocksAt = time.Now()
+}
+
+// animate 手を打つ前の盤面と比べて、返された石を動かして見せる
+func (t *tui) animate(before game.Boarder, c game.Character) {
+	now := time.Now()
+	for y := int32(1); y <= game.BoardSize; y++ {
+		for x := int32(1); x <= game.BoardSize; x++ {
+			if before.Cell(x, y) == game.OpponentCharacter(c) && t.r.game.Board.Cell(x, y) == c {
+				t.flips[[2]int32{x, y}] = now
+			}
+		}
+	}
+	t.from = game.OpponentCharacter(c)
+}
+
+// press キーを処理する。全画面を閉じる場合はtrueを返す
+func (t *tui) press(k key) (bool, error) {
+	if k.kind == keyInterrupt {
+		return true, nil
+	}
+	// 返答待ちの質問があれば、y/nだけを受け付ける
+	if t.prompt != promptNone {
+		switch {
+		case k.kind == keyRune && (k.r == 'y' || k.r == 'Y'):
+			return false, t.answer(true)
+		case k.kind == keyRune && (k.r == 'n' || k.r == 'N'), k.kind == keyEscape:
+			return false, t.answer(false)
+		}
+		return false, nil
+	}
+
+	switch k.kind {
+	case keyUp:
+		t.moveCursor(0, -1)
+	case keyDown:
+		t.moveCursor(0, 1)
+	case keyLeft:
+		if t.finished {
+			return false, t.review(-1)
+		}
+		t.moveCursor(-1, 0)
+	case keyRight:
+		if t.finished {
+			return false, t.review(1)
+		}
+		t.moveCursor(1, 0)
+	case keyEnter:
+		return false, t.place()
+	case keyClick:
+		x, y, ok := cellAt(k.col, k.row)
+		if !ok {
+			return false, nil
+		}
+		t.cursorX, t.cursorY = x, y
+		return false, t.place()
+	case keyRune:
+		return t.command(k.r)
+	}
+	return false, nil
+}
+
+// command 文字のキーに割り当てた操作
+func (t *tui) command(r rune) (bool, error) {
+	switch r {
+	case 'h':
+		t.moveCursor(-1, 0)
+	case 'j':
+		t.moveCursor(0, 1)
+	case 'k':
+		t.moveCursor(0, -1)
+	case 'l':
+		t.moveCursor(1, 0)
+	case 'b':
+		return false, t.review(-1)
+	case 'f':
+		return false, t.review(1)
+	case 'q':
+		if t.finished {
+			return true, nil
+		}
+		t.notify("The game is in progress. Press r to resign, or Ctrl-C to leave.", true)
+	}
+	if !t.started || t.finished {
+		return false, nil
+	}
+	switch r {
+	case 'u':
+		// 待ったを申し込む
+		return false, t.send(&pb.PlayRequest{Action: &pb.PlayRequest_Takeback{Takeback: &pb.TakebackAction{}}})
+	case 'd':
+		return false, t.send(commandRequest("draw"))
+	case 'a':
+		return false, t.send(commandRequest("abort"))
+	case 'r':
+		t.prompt = promptResign
+	}
+	return false, nil
+}
+
+// answer 質問にy/nで返答する
+func (t *tui) answer(accept bool) error {
+	p := t.prompt
+	t.prompt = promptNone
+	switch p {
+	case promptTakeback:
+		return t.send(&pb.PlayRequest{
+			Action: &pb.PlayRequest_TakebackReply{
+				TakebackReply: &pb.TakebackReplyAction{Accept: accept},
+			},
+		})
+	case promptDraw:
+		return t.send(&pb.PlayRequest{
+			Action: &pb.PlayRequest_DrawReply{
+				DrawReply: &pb.DrawReplyAction{Accept: accept},
+			},
+		})
+	case promptResign:
+		if accept {
+			return t.send(commandRequest("resign"))
+		}
+	}
+	return nil
+}
+
+func (t *tui) moveCursor(dx, dy int32) {
+	t.cursorX = min(max(t.cursorX+dx, 1), game.BoardSize)
+	t.cursorY = min(max(t.cursorY+dy, 1), game.BoardSize)
+}
+
+// place カーソルの位置に石を置く。盤面にはサーバーから手が届いてから反映する
+func (t *tui) place() error {
+	g, me := t.r.game, t.r.me
+	switch {
+	case t.finished || t.pending:
+		return nil
+	case !t.started:
+		t.notify("The game has not started yet.", true)
+		return nil
+	case g.Turn() != me.Character:
+		t.notify("Not your turn.", true)
+		return nil
+	case !g.Board.CanPutStone(t.cursorX, t.cursorY, me.Character):
+		t.notify(fmt.Sprintf("You can not put a stone on %v.", game.PlyNotation(game.Ply{X: t.cursorX, Y: t.cursorY})), true)
+		return nil
+	}
+	t.pending = true
+	return t.send(&pb.PlayRequest{
+		Action: &pb.PlayRequest_Move{
+			Move: &pb.MoveAction{
+				Move: &pb.Move{X: t.cursorX, Y: t.cursorY},
+			},
+		},
+	})
+}
+
+// review 終局後に手元の棋譜で盤面を行き来する
+func (t *tui) review(d int) error {
+	if !t.finished {
+		return nil
+	}
+	if err := t.r.game.Jump(t.r.game.Cursor() + d); err != nil && !errors.Is(err, game.ErrOutOfHistory) {
+		return err
+	}
+	t.flips = make(map[[2]int32]time.Time)
+	return nil
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// keyKind 全画面表示で受け取るキーの種類
+type keyKind int
+
+const (
+	keyRune keyKind = iota // 文字のキー。runeに文字が入る
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyEnter // EnterとSpace
+	keyEscape
+	keyInterrupt // Ctrl-C
+	keyClick     // マウスの左クリック。col, rowに端末の1始まりの位置が入る
+)
+
+type key struct {
+	kind keyKind
+	r    rune
+	col  int
+	row  int
+}
+
+// readKeys inからキーを読み、keysに送る。読めなくなるかctxがキャンセルされたらkeysを閉じる
+func readKeys(ctx context.Context, in io.Reader, keys chan<- key) {
+	defer close(keys)
+	buf := make([]byte, 256)
+	for {
+		n, err := in.Read(buf)
+		for _, k := range parseKeys(buf[:n]) {
+			select {
+			case keys <- k:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// parseKeys rawモードの端末から読んだバイト列をキーに分ける。矢印キーとマウス以外のエスケープシーケンスは捨てる
+func parseKeys(b []byte) []key {
+	var keys []key
+	for i := 0; i < len(b); {
+		switch c := b[i]; {
+		case c == 0x1b && i+2 < len(b) && (b[i+1] == '[' || b[i+1] == 'O'):
+			k, n := parseEscape(b[i+2:])
+			if k != nil {
+				keys = append(keys, *k)
+			}
+			i += 2 + n
+		case c == 0x1b:
+			keys = append(keys, key{kind: keyEscape})
+			i++
+		case c == 0x03:
+			keys = append(keys, key{kind: keyInterrupt})
+			i++
+		case c == '\r' || c == '\n' || c == ' ':
+			keys = append(keys, key{kind: keyEnter})
+			i++
+		default:
+			r, n := utf8.DecodeRune(b[i:])
+			keys = append(keys, key{kind: keyRune, r: r})
+			i += n
+		}
+	}
+	return keys
+}
+
+// parseEscape ESC [ に続くバイト列を解析し、キーと読んだバイト数を返す。使わないシーケンスはnil
+func parseEscape(b []byte) (*key, int) {
+	switch b[0] {
+	case 'A':
+		return &key{kind: keyUp}, 1
+	case 'B':
+		return &key{kind: keyDown}, 1
+	case 'C':
+		return &key{kind: keyRight}, 1
+	case 'D':
+		return &key{kind: keyLeft}, 1
+	case '<':
+		// SGRのマウス: ESC [ < ボタン ; 列 ; 行 M(押した) または m(離した)
+		end := strings.IndexAny(string(b), "Mm")
+		if end < 0 {
+			return nil, len(b)
+		}
+		fields := strings.Split(string(b[1:end]), ";")
+		if b[end] != 'M' || len(fields) != 3 || fields[0] != "0" {
+			return nil, end + 1
+		}
+		col, err1 := strconv.Atoi(fields[1])
+		row, err2 := strconv.Atoi(fields[2])
+		if err1 != nil || err2 != nil {
+			return nil, end + 1
+		}
+		return &key{kind: keyClick, col: col, row: row}, end + 1
+	}
+	// その他のシーケンスは終端のバイト(0x40-0x7e)まで読み飛ばす
+	for i, c := range b {
+		if 0x40 <= c && c <= 0x7e {
+			return nil, i + 1
+		}
+	}
+	return nil, len(b)
+}
@@ -0,0 +1,262 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"kazuki.matsumoto/reversi/build"
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// 全画面表示で使うANSIのエスケープシーケンス
+const (
+	escReset  = "\x1b[0m"
+	escBold   = "\x1b[1m"
+	escDim    = "\x1b[2m"
+	escRed    = "\x1b[31m"
+	escYellow = "\x1b[33m"
+	escBlack  = "\x1b[30m"
+	escWhite  = "\x1b[97m"
+	escGray   = "\x1b[90m"
+	escBoard  = "\x1b[42m" // 盤面は緑の背景
+	escLast   = "\x1b[43m" // 最後の手は黄色の背景
+	escCursor = "\x1b[44m" // カーソルは青の背景
+)
+
+const (
+	// boardTop 盤面の1行目を描く画面の行(1始まり)から1を引いたもの。y行目は画面のboardTop+y行目になる
+	boardTop = 3
+	// boardWidth 盤面の列の幅。右に並べる情報はこの後から描く
+	boardWidth = 20
+	// moveRows 棋譜を表示する最大の行数。超えた分は古い手から隠す
+	moveRows = 12
+)
+
+// cellAt クリックされた画面の位置(1始まり)を盤面の座標にする。盤面の外ならfalse
+// 各行は行番号の1文字に続けて1マス2文字で描くので、x列目は画面の2x列目と2x+1列目になる
+func cellAt(col, row int) (int32, int32, bool) {
+	x, y := int32(col/2), int32(row-boardTop)
+	if x < 1 || game.BoardSize < x || y < 1 || game.BoardSize < y {
+		return 0, 0, false
+	}
+	return x, y, true
+}
+
+// draw 画面全体を描き直す。前の画面を消さずに上書きするので、ちらつかない
+func (t *tui) draw() {
+	now := time.Now()
+	for c, at := range t.flips {
+		if now.Sub(at) >= flipDuration {
+			delete(t.flips, c)
+		}
+	}
+
+	lines := []string{t.title(), ""}
+	board := t.board(now)
+	panel := t.panel(now)
+	for i := 0; i < max(len(board), len(panel)); i++ {
+		left := strings.Repeat(" ", boardWidth)
+		if i < len(board) {
+			left = board[i]
+		}
+		right := ""
+		if i < len(panel) {
+			right = panel[i]
+		}
+		lines = append(lines, left+right)
+	}
+	lines = append(lines, "", t.statusLine())
+	for _, line := range t.result {
+		lines = append(lines, escBold+line+escReset)
+	}
+	lines = append(lines, "", escDim+t.help()+escReset)
+
+	var sb strings.Builder
+	sb.WriteString("\x1b[H")
+	for _, line := range lines {
+		// rawモードでは改行で行頭に戻らないので\rも書く。前の画面の残りは行末まで消す
+		sb.WriteString(line + "\x1b[K\r\n")
+	}
+	sb.WriteString("\x1b[J")
+	os.Stdout.WriteString(sb.String())
+}
+
+func (t *tui) title() string {
+	s := escBold + "Reversi" + escReset + "  " + t.r.room.ID
+	if tc := t.r.room.TimeControl; tc.Enabled() {
+		s += "  " + timeControlString(tc)
+	}
+	return s
+}
+
+// board 列の見出しと8行の盤面。どの行も見た目の幅はboardWidthに揃える
+func (t *tui) board(now time.Time) []string {
+	g := t.r.game
+	last, hasLast := g.LastMove()
+	// 自分の手番で、手を送っていなければ置ける場所に印をつける
+	hints := t.started && !t.finished && !t.pending && g.Turn() == t.r.me.Character
+
+	header := " "
+	for c := 'A'; c < 'A'+game.BoardSize; c++ {
+		header += " " + string(c)
+	}
+	lines := []string{escDim + header + escReset + strings.Repeat(" ", boardWidth-len(header))}
+	for y := int32(1); y <= game.BoardSize; y++ {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "%v%d%v", escDim, y, escReset)
+		for x := int32(1); x <= game.BoardSize; x++ {
+			bg := escBoard
+			if hasLast && last.X == x && last.Y == y {
+				bg = escLast
+			}
+			if !t.finished && t.cursorX == x && t.cursorY == y {
+				bg = escCursor
+			}
+			sb.WriteString(bg + " " + t.cell(x, y, hints, now) + escReset)
+		}
+		sb.WriteString(escBoard + " " + escReset + strings.Repeat(" ", boardWidth-2-2*game.BoardSize))
+		lines = append(lines, sb.String())
+	}
+	return lines
+}
+
+// cell 1マスの中身。返したばかりの石は、元の色、横から見た石、新しい色の順に見せる
+func (t *tui) cell(x, y int32, hints bool, now time.Time) string {
+	c := t.r.game.Board.Cell(x, y)
+	if at, ok := t.flips[[2]int32{x, y}]; ok {
+		switch now.Sub(at) * 3 / flipDuration {
+		case 0:
+			return stone(t.from)
+		case 1:
+			return escBold + escGray + "|"
+		}
+	}
+	switch {
+	case c == game.Black || c == game.White:
+		return stone(c)
+	case hints && t.r.game.Board.CanPutStone(x, y, t.r.me.Character):
+		return escYellow + "·"
+	}
+	return " "
+}
+
+func stone(c game.Character) string {
+	if c == game.White {
+		return escBold + escWhite + "●"
+	}
+	return escBold + escBlack + "●"
+}
+
+// panel 盤面の右に並べる対局者、残り時間、石の数、棋譜
+func (t *tui) panel(now time.Time) []string {
+	g := t.r.game
+	lines := []string{""}
+	for _, c := range []game.Character{game.Black, game.White} {
+		lines = append(lines, t.player(c, now))
+	}
+	lines = append(lines, "  Opponent: "+t.opponentStatus(), "")
+	lines = append(lines, fmt.Sprintf("  Score: BLACK=%d, WHITE=%d REST=%d",
+		g.Board.Score(game.Black), g.Board.Score(game.White), g.Board.Rest()), "")
+
+	lines = append(lines, "  Moves")
+	history := g.History()
+	var moves []string
+	for i := 0; i < len(history); i += 2 {
+		s := fmt.Sprintf("  %3d. %v", i/2+1, game.PlyNotation(history[i]))
+		if i+1 < len(history) {
+			s += " " + game.PlyNotation(history[i+1])
+		}
+		moves = append(moves, s)
+	}
+	if len(moves) > moveRows {
+		moves = moves[len(moves)-moveRows:]
+	}
+	return append(lines, moves...)
+}
+
+// player 色cの対局者の名前と残り時間。手番の色には印をつける
+func (t *tui) player(c game.Character, now time.Time) string {
+	p := t.r.me
+	if c != p.Character {
+		p = t.r.opponent()
+	}
+	mark := "  "
+	if t.started && !t.finished && t.r.game.Turn() == c {
+		mark = escBold + "▶ " + escReset
+	}
+	name := p.Name
+	if p.Bot {
+		name = "AI"
+	}
+	if p.ID == t.r.me.ID {
+		name += " (You)"
+	}
+	s := fmt.Sprintf("%v%-5v  %-20v", mark, build.PBCharacter(c), name)
+	if clock := t.clock(c, now); clock != nil {
+		color := ""
+		if clock.GetRemainingMs() < 10*1000 {
+			color = escRed
+		}
+		s += color + clockString(clock) + escReset
+	}
+	return s
+}
+
+// clock 色cの残り時間。進んでいる時計は、受け取ってから経った時間を手元で引く。持ち時間がない部屋ではnil
+func (t *tui) clock(c game.Character, now time.Time) *pb.Clock {
+	if t.clocks == nil {
+		return nil
+	}
+	clock := t.clocks.GetBlack()
+	if c == game.White {
+		clock = t.clocks.GetWhite()
+	}
+	remaining := clock.GetRemainingMs()
+	if t.started && !t.finished && build.Character(t.clocks.GetRunning()) == c {
+		remaining = max(remaining-now.Sub(t.clocksAt).Milliseconds(), 0)
+	}
+	return &pb.Clock{RemainingMs: remaining, Byoyomi: clock.GetByoyomi()}
+}
+
+// opponentStatus 相手が何をしているか
+func (t *tui) opponentStatus() string {
+	switch {
+	case !t.started:
+		return "getting ready"
+	case t.finished:
+		return "game over"
+	case t.waiting:
+		return "considering your offer"
+	case t.r.game.Turn() != t.r.me.Character:
+		return "thinking..."
+	case t.passed:
+		return "passed"
+	}
+	return "waiting for your move"
+}
+
+// statusLine 返答を待っている質問か、最後のお知らせ
+func (t *tui) statusLine() string {
+	switch t.prompt {
+	case promptTakeback:
+		return escBold + escYellow + "Opponent requests a takeback. Accept? (y/n)" + escReset
+	case promptDraw:
+		return escBold + escYellow + "Opponent offers a draw. Accept? (y/n)" + escReset
+	case promptResign:
+		return escBold + escYellow + "Resign this game? (y/n)" + escReset
+	}
+	if t.alert {
+		return escRed + t.status + escReset
+	}
+	return t.status
+}
+
+func (t *tui) help() string {
+	if t.finished {
+		return fmt.Sprintf("[%d/%d]  ←→/b f review  q quit", t.r.game.Cursor(), t.r.game.Len())
+	}
+	return "←↓↑→/hjkl move  Enter/click place  u undo  d draw  a abort  r resign  Ctrl-C leave"
+}
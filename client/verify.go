@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"kazuki.matsumoto/reversi/build"
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// rotateSeed 自分で決めた種で抽選の種を入れ替える。前の種が明かされ、それまでの報酬を-verifyで確かめられる。
+// 種を指定していなければ乱数で決める。使い始める種は、自分の種を送る前にマッチングで公開された種でなければならない
+func (r *Reversi) rotateSeed(ctx context.Context, cli pb.RewardServiceClient) error {
+	clientSeed := r.cfg.ClientSeed
+	if clientSeed == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return err
+		}
+		clientSeed = hex.EncodeToString(b)
+	}
+	res, err := cli.RotateSeed(r.authorized(ctx), &pb.RotateSeedRequest{ClientSeed: clientSeed})
+	if err != nil {
+		return err
+	}
+	if hash := res.GetNext().GetServerSeedHash(); hash != r.nextSeedHash {
+		return fmt.Errorf("server seed hash %v does not match the hash published at matching %v", hash, r.nextSeedHash)
+	}
+	if revealed := res.GetRevealed(); revealed != nil {
+		fmt.Printf("Revealed server seed: %v\n", revealed.GetServerSeed())
+	}
+	fmt.Printf("Reward seed hash: %v (client seed %q)\n", res.GetNext().GetServerSeedHash(), res.GetNext().GetClientSeed())
+	r.seedHash, r.nextSeedHash = res.GetNext().GetServerSeedHash(), res.GetNextServerSeedHash()
+	return nil
+}
+
+// verify 明かされた種で報酬の抽選をやり直し、付与された報酬と一致するかを確かめる
+func (r *Reversi) verify(ctx context.Context, cli pb.RewardServiceClient) error {
+	if r.cfg.Name == "" {
+		return errors.New("-verify requires -name")
+	}
+	odds, err := cli.GetOdds(ctx, &pb.GetOddsRequest{})
+	if err != nil {
+		return err
+	}
+	history, err := cli.ListRewardHistory(ctx, &pb.ListRewardHistoryRequest{Name: r.cfg.Name})
+	if err != nil {
+		return err
+	}
+	pools := make(map[string]*pb.OddsPool, len(odds.GetPools()))
+	for _, p := range odds.GetPools() {
+		pools[p.GetName()] = p
+	}
+
+	failed := 0
+	for _, reward := range history.GetRewards() {
+		result := verifyReward(reward, pools[reward.GetPool()])
+		if result != "ok" && result != "pending" {
+			failed++
+		}
+		fmt.Printf("%-8v %-24v %v nonce=%v\n", result, reward.GetCardId(), reward.GetRoomId(), reward.GetNonce())
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d rewards could not be verified", failed)
+	}
+	return nil
+}
+
+// verifyReward 1件の報酬を確かめる。種をまだ明かしていなければpending
+func verifyReward(reward *pb.Reward, pool *pb.OddsPool) string {
+	seed := reward.GetSeed()
+	switch {
+	case seed == nil:
+		return "noseed"
+	case seed.GetServerSeed() == "":
+		return "pending"
+	case !game.VerifySeed(seed.GetServerSeed(), seed.GetServerSeedHash()):
+		return "badhash"
+	case pool == nil:
+		// 抽選表から外されたプールは、提供割合がわからないので確かめられない
+		return "nopool"
+	}
+
+	items := pool.GetItems()
+	if reward.GetPity() {
+		items = topItems(items)
+	}
+	rnd := game.NewFairRandom(seed.GetServerSeed(), seed.GetClientSeed(), reward.GetNonce())
+	if game.DrawFrom(build.Rewards(items), rnd).CardID != reward.GetCardId() {
+		// 抽選した後に提供割合が変わった場合も一致しない
+		return "mismatch"
+	}
+	return "ok"
+}
+
+// topItems 最高レアリティのカード。天井で抽選した報酬を確かめるのに使う
+func topItems(items []*pb.OddsItem) []*pb.OddsItem {
+	var top int32
+	for _, item := range items {
+		top = max(top, item.GetRarity())
+	}
+	var res []*pb.OddsItem
+	for _, item := range items {
+		if item.GetRarity() == top {
+			res = append(res, item)
+		}
+	}
+	return res
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"kazuki.matsumoto/reversi/build"
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// watch 部屋を観戦する。手は打てないので、送られてくる対局の状態を表示するだけ
+func (r *Reversi) watch(ctx context.Context, cli pb.GameServiceClient) error {
+	stream, err := cli.Play(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.CloseSend()
+
+	err = stream.Send(&pb.PlayRequest{
+		RoomId: r.cfg.Watch,
+		Action: &pb.PlayRequest_Watch{
+			Watch: &pb.WatchAction{},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	r.game = game.NewGame(game.None)
+	fmt.Printf("Watching room_id=%v\n", r.cfg.Watch)
+
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		switch res.GetEvent().(type) {
+		case *pb.PlayResponse_Snapshot:
+			// 観戦開始時点までの棋譜で盤面を作る
+			snapshot := res.GetSnapshot()
+			if err := r.restore(snapshot.GetMoves()); err != nil {
+				return err
+			}
+			display(r.game, r.cfg.Renderer)
+			if !snapshot.GetStarted() {
+				fmt.Println("Waiting until players ready")
+			}
+			printClocks(snapshot.GetClocks())
+		case *pb.PlayResponse_Ready:
+			fmt.Println("Game started!")
+		case *pb.PlayResponse_Move:
+			move := res.GetMove()
+			character := build.Character(move.GetPlayer().GetCharacter())
+			fmt.Printf("\n%v: %v\n", build.PBCharacter(character), game.PlyNotation(game.Ply{X: move.GetMove().GetX(), Y: move.GetMove().GetY()}))
+			if _, err := r.game.Move(move.GetMove().GetX(), move.GetMove().GetY(), character); err != nil {
+				return err
+			}
+			display(r.game, r.cfg.Renderer)
+			printClocks(move.GetClocks())
+		case *pb.PlayResponse_Pass:
+			character := build.Character(res.GetPass().GetPlayer().GetCharacter())
+			if err := r.game.Pass(character); err != nil {
+				return err
+			}
+			fmt.Printf("\n%v has no move. Pass.\n", build.PBCharacter(character))
+		case *pb.PlayResponse_Takeback:
+			takeback := res.GetTakeback()
+			if !takeback.GetAccepted() {
+				break
+			}
+			if err := r.game.Jump(int(takeback.GetPly())); err != nil {
+				return err
+			}
+			fmt.Println("\nTakeback accepted.")
+			display(r.game, r.cfg.Renderer)
+		case *pb.PlayResponse_Error:
+			return errors.New(res.GetError().GetMessage())
+		case *pb.PlayResponse_Finished:
+			winner := build.Character(res.GetFinished().GetWinner())
+			fmt.Println("")
+			printTermination(res.GetFinished(), game.None)
+			if build.Termination(res.GetFinished().GetTermination()) == game.Aborted {
+				// 中止の場合は勝敗をつけない
+			} else if winner == game.None {
+				fmt.Println("Draw!")
+			} else {
+				fmt.Printf("%v Win!\n", build.PBCharacter(winner))
+			}
+			fmt.Printf("Transcript: %v\n", r.game.Transcript())
+			return nil
+		}
+	}
+}
@@ -1,10 +1,85 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"kazuki.matsumoto/reversi/client"
+	"kazuki.matsumoto/reversi/game"
 	"os"
+	"path/filepath"
+	"time"
 )
 
 func main() {
-	os.Exit(client.NewReversi().Run())
+	bot := flag.Bool("bot", false, "対戦相手が見つからない場合にAIと対戦する")
+	clock := flag.String("clock", "none", "希望する持ち時間の方式(none, absolute, fischer, byoyomi)")
+	anyClock := flag.Bool("anyclock", false, "相手が希望する持ち時間でも対戦する")
+	color := flag.String("color", "any", "希望する色(any, black, white)")
+	mainTime := flag.Duration("main", 5*time.Minute, "持ち時間")
+	increment := flag.Duration("increment", 5*time.Second, "fischerで1手ごとに加算される時間")
+	byoyomi := flag.Duration("byoyomi", 30*time.Second, "byoyomiで持ち時間を使い切った後、1手あたりに使える時間")
+	watch := flag.String("watch", "", "指定した部屋IDの対局を観戦する")
+	name := flag.String("name", "", "プレイヤー名。初めて使う名前は登録され、同じ名前で参加し直すとレーティングが引き継がれる。他のプレイヤーが登録した名前ならゲストになる")
+	leaderboard := flag.Bool("leaderboard", false, "対局せずにランキングを表示する")
+	create := flag.Bool("create", false, "マッチングせずに招待コード付きの部屋を作成する。持ち時間と色の指定は部屋の設定になる")
+	private := flag.Bool("private", false, "-createで作成する部屋を一覧に表示しない")
+	noSpectators := flag.Bool("nospectators", false, "-createで作成する部屋の観戦を許可しない")
+	code := flag.String("code", "", "招待コードの部屋に参加する")
+	rooms := flag.Bool("rooms", false, "対局せずにゲストを待っている部屋の一覧を表示する")
+	verify := flag.Bool("verify", false, "対局せずに、-nameのプレイヤーの報酬を明かされた種で抽選し直して確かめる")
+	clientSeed := flag.String("clientseed", "", "マッチング後に、報酬の抽選の種をこの種で入れ替える。前の種が明かされ、-verifyで確かめられる")
+	inventory := flag.Bool("inventory", false, "対局せずに、-nameのプレイヤーが持っているカードと報酬の履歴を表示する")
+	board := flag.String("board", "unicode", "盤面の描き方(unicode, ascii, ansi, compact)")
+	hints := flag.Bool("hints", false, "手番の色が石を置ける場所に印をつける")
+	lastMove := flag.Bool("lastmove", true, "最後に石が置かれた場所を強調する")
+	tui := flag.Bool("tui", false, "全画面で対局する。カーソルキーかマウスで手を選ぶ")
+	credentials := flag.String("credentials", defaultCredentials(), "-nameで登録した名前のトークンを保存するファイル。同じ名前で参加し直すのに使う")
+	flag.Parse()
+
+	tc, err := client.ParseTimeControl(*clock, *mainTime, *increment, *byoyomi)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	c, err := client.ParseColor(*color)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	renderer, err := client.ParseRenderer(*board, game.RenderOptions{Hints: *hints, LastMove: *lastMove})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+
+	os.Exit(client.NewReversi(client.Config{
+		AllowBot:       *bot,
+		TimeControl:    tc,
+		AnyTimeControl: *anyClock,
+		Color:          c,
+		Watch:          *watch,
+		Name:           *name,
+		Leaderboard:    *leaderboard,
+		CreateRoom:     *create,
+		Private:        *private,
+		NoSpectators:   *noSpectators,
+		InviteCode:     *code,
+		ListRooms:      *rooms,
+		Inventory:      *inventory,
+		Verify:         *verify,
+		ClientSeed:     *clientSeed,
+		Renderer:       renderer,
+		TUI:            *tui,
+		Credentials:    *credentials,
+	}).Run())
+}
+
+// defaultCredentials ユーザーの設定ディレクトリのファイル。設定ディレクトリがなければ保存しない
+func defaultCredentials() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "reversi", "credentials.json")
 }
@@ -0,0 +1,217 @@
+// Package ai リバーシのAI。反復深化のαβ探索で手を選び、終盤は完全読みに切り替える
+package ai
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+// ErrNoMove 置ける場所がない(パスするしかない)
+var ErrNoMove = errors.New("no available move")
+
+const (
+	// 終局時の評価値。どんな評価関数の値よりも大きくなるよう石差に掛ける
+	winScore = 1 << 20
+	infinity = math.MaxInt32
+
+	// 時間切れの確認を何ノードごとに行うか
+	checkInterval = 1024
+)
+
+// Config 探索の設定
+type Config struct {
+	Depth     int           // 最大探索深さ
+	TimeLimit time.Duration // 1手あたりの思考時間。ゼロなら深さのみで打ち切る
+	Evaluator Evaluator     // nilならDefaultEvaluator
+	// SolveEmpties 空きマスがこの数以下なら完全読みで打つ。ゼロなら完全読みしない。
+	// 読み切れなかった場合は残りの時間で通常の探索に戻る。どちらも同じTimeLimitまでに打ち切る
+	SolveEmpties int
+}
+
+// DefaultConfig サーバーで対戦相手として使う設定
+func DefaultConfig() Config {
+	return Config{
+		Depth:        8,
+		TimeLimit:    1 * time.Second,
+		Evaluator:    DefaultEvaluator(),
+		SolveEmpties: DefaultSolveEmpties,
+	}
+}
+
+// Engine 複数の部屋から同時に使える
+type Engine struct {
+	cfg Config
+	// solvers 完全読みのSolver。置換表が大きいので手ごとに作らず、使い終わったら戻して使い回す
+	solvers sync.Pool
+}
+
+func NewEngine(cfg Config) *Engine {
+	if cfg.Evaluator == nil {
+		cfg.Evaluator = DefaultEvaluator()
+	}
+	if cfg.Depth < 1 {
+		cfg.Depth = 1
+	}
+	e := &Engine{cfg: cfg}
+	e.solvers.New = func() any {
+		return NewSolver(0)
+	}
+	return e
+}
+
+// search 1回の探索の状態。時間切れになったらabortedを立てて探索を打ち切る
+type search struct {
+	eval     Evaluator
+	deadline time.Time
+	nodes    int
+	aborted  bool
+}
+
+// Move cの手番で打つ手を選ぶ。置ける場所がなければErrNoMove
+func (e *Engine) Move(b game.Boarder, c game.Character) (int32, int32, error) {
+	bb := game.NewBitBoardFrom(b)
+	p, o := bb.Stones(c), bb.Stones(game.OpponentCharacter(c))
+
+	moves := orderedMoves(game.LegalMoves(p, o))
+	if len(moves) == 0 {
+		return 0, 0, ErrNoMove
+	}
+	if len(moves) == 1 {
+		x, y := game.BitToCell(moves[0])
+		return x, y, nil
+	}
+
+	// 完全読みと通常の探索で同じ期限を使い、合わせてTimeLimitに収める
+	var deadline time.Time
+	if e.cfg.TimeLimit > 0 {
+		deadline = time.Now().Add(e.cfg.TimeLimit)
+	}
+
+	// 終盤は最後まで読み切って最善手を打つ
+	if bb.Rest() <= e.cfg.SolveEmpties {
+		solver := e.solvers.Get().(*Solver)
+		sol, err := solver.solveBy(bb, c, deadline)
+		e.solvers.Put(solver)
+		if err == nil && len(sol.Moves) > 0 {
+			return sol.Moves[0].X, sol.Moves[0].Y, nil
+		}
+	}
+
+	s := &search{eval: e.cfg.Evaluator, deadline: deadline}
+
+	// 反復深化。浅い探索の最善手を次の深さで最初に調べることで枝刈りが効きやすくなる
+	best := moves[0]
+	for depth := 1; depth <= e.cfg.Depth; depth++ {
+		m, ok := s.root(p, o, moves, depth)
+		if !ok {
+			// 時間切れで途中までの結果は信用できないので、ひとつ前の深さの結果を使う
+			break
+		}
+		best = m
+		moves = moveToFront(moves, best)
+	}
+
+	x, y := game.BitToCell(best)
+	return x, y, nil
+}
+
+func (s *search) root(p uint64, o uint64, moves []uint64, depth int) (uint64, bool) {
+	alpha, beta := -infinity, infinity
+	var best uint64
+	for _, m := range moves {
+		f := game.Flips(p, o, m)
+		v := -s.negamax(o&^f, p|m|f, depth-1, -beta, -alpha, false)
+		if s.aborted {
+			return 0, false
+		}
+		if v > alpha || best == 0 {
+			alpha = v
+			best = m
+		}
+	}
+	return best, true
+}
+
+// negamax 手番側から見た評価値を返す。passedは直前の手番がパスだったか
+func (s *search) negamax(p uint64, o uint64, depth int, alpha int, beta int, passed bool) int {
+	s.nodes++
+	if s.nodes%checkInterval == 0 && !s.deadline.IsZero() && time.Now().After(s.deadline) {
+		s.aborted = true
+	}
+	if s.aborted {
+		return 0
+	}
+
+	legal := game.LegalMoves(p, o)
+	if legal == 0 {
+		if passed {
+			// 双方置けないので終局。石差で評価する
+			return finalScore(p, o)
+		}
+		return -s.negamax(o, p, depth, -beta, -alpha, true)
+	}
+	if depth <= 0 {
+		return s.eval.Evaluate(p, o)
+	}
+
+	best := -infinity
+	for _, m := range orderedMoves(legal) {
+		f := game.Flips(p, o, m)
+		v := -s.negamax(o&^f, p|m|f, depth-1, -beta, -alpha, false)
+		if v > best {
+			best = v
+		}
+		if v > alpha {
+			alpha = v
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return best
+}
+
+func finalScore(p uint64, o uint64) int {
+	diff := bits.OnesCount64(p) - bits.OnesCount64(o)
+	return diff * winScore
+}
+
+// 着手の優先度。隅を先に、隅の隣(X打ち、C打ち)を後に調べる
+const (
+	xSquares uint64 = 0x0042000000004200 // B2, G2, B7, G7
+	cSquares uint64 = 0x4281000000008142 // 隅に隣接する辺のマス
+)
+
+// orderedMoves 置けるマスのbit集合を、有望そうな順に1マスずつのbitに分解する
+func orderedMoves(legal uint64) []uint64 {
+	moves := make([]uint64, 0, bits.OnesCount64(legal))
+	for _, group := range []uint64{
+		legal & corners,
+		legal &^ (corners | xSquares | cSquares),
+		legal & cSquares,
+		legal & xSquares,
+	} {
+		for group != 0 {
+			m := group & -group
+			moves = append(moves, m)
+			group &^= m
+		}
+	}
+	return moves
+}
+
+func moveToFront(moves []uint64, m uint64) []uint64 {
+	for i, v := range moves {
+		if v == m {
+			copy(moves[1:i+1], moves[:i])
+			moves[0] = m
+			break
+		}
+	}
+	return moves
+}
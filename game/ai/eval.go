@@ -0,0 +1,93 @@
+package ai
+
+import (
+	"math/bits"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+// Evaluator 盤面の評価関数。pが手番側の石、oが相手の石で、手番側から見た評価値を返す
+type Evaluator interface {
+	Evaluate(p uint64, o uint64) int
+}
+
+// EvaluatorFunc 関数をEvaluatorとして扱うためのアダプタ
+type EvaluatorFunc func(p uint64, o uint64) int
+
+func (f EvaluatorFunc) Evaluate(p uint64, o uint64) int {
+	return f(p, o)
+}
+
+const (
+	corners uint64 = 0x8100000000000081 // A1, H1, A8, H8
+	edgeTop uint64 = 0x00000000000000ff
+	edgeBtm uint64 = 0xff00000000000000
+	edgeLft uint64 = 0x0101010101010101
+	edgeRgt uint64 = 0x8080808080808080
+)
+
+// WeightedEvaluator 着手可能数、隅、確定石、偶数理論の4要素を重み付けして評価する
+type WeightedEvaluator struct {
+	Mobility  int // 着手可能数の差1つあたり
+	Corner    int // 隅の石の差1つあたり
+	Stability int // 確定石の差1つあたり
+	Parity    int // 残りマスが奇数(手番側が最後に打てる)の場合の加点
+}
+
+// DefaultEvaluator 標準の重み
+func DefaultEvaluator() *WeightedEvaluator {
+	return &WeightedEvaluator{
+		Mobility:  10,
+		Corner:    100,
+		Stability: 30,
+		Parity:    20,
+	}
+}
+
+func (e *WeightedEvaluator) Evaluate(p uint64, o uint64) int {
+	score := 0
+
+	// 着手可能数。多いほど相手の選択肢を狭められる
+	score += e.Mobility * (bits.OnesCount64(game.LegalMoves(p, o)) - bits.OnesCount64(game.LegalMoves(o, p)))
+
+	// 隅は一度取ったら返されない
+	score += e.Corner * (bits.OnesCount64(p&corners) - bits.OnesCount64(o&corners))
+
+	// 確定石
+	score += e.Stability * (bits.OnesCount64(stable(p)) - bits.OnesCount64(stable(o)))
+
+	// 偶数理論。残りマスが奇数なら手番側が最後の1マスを打てる
+	empty := bits.OnesCount64(^(p | o))
+	if empty%2 == 1 {
+		score += e.Parity
+	} else {
+		score -= e.Parity
+	}
+	return score
+}
+
+// stable 隅から辺に沿って同じ色が連続している石を確定石とみなす。
+// 内側の確定石までは求めないが、評価関数で毎回呼べる程度に軽い
+func stable(p uint64) uint64 {
+	var s uint64
+	// 隅ごとに、辺を2方向に伸ばす
+	s |= walk(p, 1<<0, 1, edgeTop) | walk(p, 1<<0, 8, edgeLft)
+	s |= walk(p, 1<<7, -1, edgeTop) | walk(p, 1<<7, 8, edgeRgt)
+	s |= walk(p, 1<<56, 1, edgeBtm) | walk(p, 1<<56, -8, edgeLft)
+	s |= walk(p, 1<<63, -1, edgeBtm) | walk(p, 1<<63, -8, edgeRgt)
+	return s
+}
+
+// walk 隅のbitから辺edgeに沿ってstepずつ進み、pの石が途切れるまでのbit集合を返す
+func walk(p uint64, corner uint64, step int, edge uint64) uint64 {
+	var s uint64
+	for m := corner; m&edge&p != 0; {
+		s |= m
+		if step > 0 {
+			m <<= uint(step)
+		} else {
+			m >>= uint(-step)
+		}
+	}
+	return s
+}
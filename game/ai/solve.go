@@ -0,0 +1,296 @@
+package ai
+
+import (
+	"errors"
+	"math/bits"
+	"time"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+// ErrTimeout 制限時間内に読み切れなかった
+var ErrTimeout = errors.New("solver timed out")
+
+const (
+	// DefaultSolveEmpties 空きマスがこの数以下になったら完全読みに切り替える
+	DefaultSolveEmpties = 14
+
+	// 置換表を使う最小の空きマス数。これより少なければ置換表を引くコストの方が大きいのでsearchShallowで読む
+	ttMinEmpties = 6
+	// 置換表のエントリ数。2の累乗にしてハッシュ値の下位bitで引く
+	ttSize = 1 << 18
+	// 速さ優先(相手の着手可能数が少ない順)で手を並べ替える最小の空きマス数
+	fastestFirstEmpties = 7
+
+	maxDiff = game.BoardSize * game.BoardSize
+)
+
+// Solution 完全読みの結果
+type Solution struct {
+	Diff  int        // 双方が最善を尽くした場合の、手番側から見た最終石差
+	Moves []game.Ply // 最善進行。パスも含む
+}
+
+// MoveValue ある手を打った場合の、手番側から見た最終石差
+type MoveValue struct {
+	X    int32
+	Y    int32
+	Diff int
+}
+
+// ttEntry 置換表のエントリ。αβ探索の結果は上限か下限しか分からないことがあるので両方持つ。
+// 同じ位置に別の局面が入ることがあるので、局面そのものも持って一致を確認する
+type ttEntry struct {
+	p     uint64
+	o     uint64
+	lower int8
+	upper int8
+	best  uint64
+}
+
+// Solver 終盤の完全読み。置換表は同じSolverで続けて読む場合に再利用する
+type Solver struct {
+	TimeLimit time.Duration // ゼロなら時間制限なし
+
+	tt       []ttEntry
+	deadline time.Time
+	nodes    int
+	aborted  bool
+}
+
+func NewSolver(timeLimit time.Duration) *Solver {
+	return &Solver{
+		TimeLimit: timeLimit,
+		tt:        make([]ttEntry, ttSize),
+	}
+}
+
+// Solve cの手番から最後まで読み切り、最終石差と最善進行を返す
+func (s *Solver) Solve(b game.Boarder, c game.Character) (*Solution, error) {
+	return s.solveBy(b, c, s.deadlineFromNow())
+}
+
+// solveBy Solveと同じだが、TimeLimitではなくdeadlineまでに読み切る。ゼロなら時間制限なし
+func (s *Solver) solveBy(b game.Boarder, c game.Character, deadline time.Time) (*Solution, error) {
+	bb := game.NewBitBoardFrom(b)
+	p, o := bb.Stones(c), bb.Stones(game.OpponentCharacter(c))
+
+	s.begin(deadline)
+	diff := s.search(p, o, -maxDiff, maxDiff)
+	if s.aborted {
+		return nil, ErrTimeout
+	}
+
+	moves, err := s.principalVariation(p, o, c, diff)
+	if err != nil {
+		return nil, err
+	}
+	return &Solution{Diff: diff, Moves: moves}, nil
+}
+
+// Values cの手番で置ける全ての手について、それぞれ打った場合の最終石差を返す。
+// 感想戦で「どの手で勝ちを逃したか」を調べるのに使う
+func (s *Solver) Values(b game.Boarder, c game.Character) ([]MoveValue, error) {
+	bb := game.NewBitBoardFrom(b)
+	p, o := bb.Stones(c), bb.Stones(game.OpponentCharacter(c))
+
+	s.begin(s.deadlineFromNow())
+	var values []MoveValue
+	for _, m := range s.orderMoves(p, o, game.LegalMoves(p, o)) {
+		f := game.Flips(p, o, m)
+		v := -s.search(o&^f, p|m|f, -maxDiff, maxDiff)
+		if s.aborted {
+			return nil, ErrTimeout
+		}
+		x, y := game.BitToCell(m)
+		values = append(values, MoveValue{X: x, Y: y, Diff: v})
+	}
+	return values, nil
+}
+
+func (s *Solver) begin(deadline time.Time) {
+	s.nodes = 0
+	s.aborted = false
+	s.deadline = deadline
+}
+
+// deadlineFromNow 今からTimeLimit後の時刻。時間制限がなければゼロ
+func (s *Solver) deadlineFromNow() time.Time {
+	if s.TimeLimit <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(s.TimeLimit)
+}
+
+// principalVariation 読み切った石差diffを実現する手を1手ずつ辿る
+func (s *Solver) principalVariation(p uint64, o uint64, c game.Character, diff int) ([]game.Ply, error) {
+	var moves []game.Ply
+	for {
+		legal := game.LegalMoves(p, o)
+		if legal == 0 {
+			if game.LegalMoves(o, p) == 0 {
+				return moves, nil
+			}
+			moves = append(moves, game.Ply{Character: c, Pass: true})
+			p, o, c, diff = o, p, game.OpponentCharacter(c), -diff
+			continue
+		}
+
+		found := false
+		for _, m := range s.orderMoves(p, o, legal) {
+			f := game.Flips(p, o, m)
+			// 石差がdiffになるかだけ分かれば良いので、diffを挟む狭い窓で調べる
+			v := -s.search(o&^f, p|m|f, -diff-1, -diff+1)
+			if s.aborted {
+				return nil, ErrTimeout
+			}
+			if v != diff {
+				continue
+			}
+			x, y := game.BitToCell(m)
+			moves = append(moves, game.Ply{Character: c, X: x, Y: y})
+			p, o, c, diff = o&^f, p|m|f, game.OpponentCharacter(c), -diff
+			found = true
+			break
+		}
+		if !found {
+			return nil, errors.New("principal variation not found")
+		}
+	}
+}
+
+// search 手番側から見た最終石差をαβ探索で求める
+func (s *Solver) search(p uint64, o uint64, alpha int, beta int) int {
+	s.nodes++
+	if s.nodes%checkInterval == 0 && !s.deadline.IsZero() && time.Now().After(s.deadline) {
+		s.aborted = true
+	}
+	if s.aborted {
+		return 0
+	}
+
+	empties := bits.OnesCount64(^(p | o))
+	if empties < ttMinEmpties {
+		return s.searchShallow(p, o, alpha, beta, false)
+	}
+
+	legal := game.LegalMoves(p, o)
+	if legal == 0 {
+		if game.LegalMoves(o, p) == 0 {
+			// 双方置けないので終局
+			return bits.OnesCount64(p) - bits.OnesCount64(o)
+		}
+		return -s.search(o, p, -beta, -alpha)
+	}
+
+	slot := &s.tt[hash(p, o)&(ttSize-1)]
+	entry := *slot
+	if entry.p != p || entry.o != o {
+		entry = ttEntry{p: p, o: o, lower: -maxDiff, upper: maxDiff}
+	} else {
+		// 置換表の範囲で窓を狭める。確定していればそのまま返す
+		if entry.lower == entry.upper || int(entry.lower) >= beta {
+			return int(entry.lower)
+		}
+		if int(entry.upper) <= alpha {
+			return int(entry.upper)
+		}
+		alpha = max(alpha, int(entry.lower))
+		beta = min(beta, int(entry.upper))
+	}
+
+	origAlpha, origBeta := alpha, beta
+	best, bestMove := -maxDiff-1, uint64(0)
+	moves := s.orderMoves(p, o, legal)
+	if entry.best&legal != 0 {
+		moves = moveToFront(moves, entry.best)
+	}
+	for _, m := range moves {
+		f := game.Flips(p, o, m)
+		v := -s.search(o&^f, p|m|f, -beta, -alpha)
+		if v > best {
+			best, bestMove = v, m
+		}
+		if v > alpha {
+			alpha = v
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	if s.aborted {
+		return best
+	}
+
+	// 窓の外で打ち切られた場合は上限/下限しか分からない
+	switch {
+	case best <= origAlpha:
+		entry.upper = int8(best)
+	case best >= origBeta:
+		entry.lower = int8(best)
+	default:
+		entry.lower, entry.upper = int8(best), int8(best)
+	}
+	entry.best = bestMove
+	*slot = entry
+	return best
+}
+
+// hash 局面のハッシュ値。置換表の位置を決めるだけなので、乗算で混ぜる程度で十分
+func hash(p uint64, o uint64) uint64 {
+	h := p*0x9e3779b97f4a7c15 ^ o*0xc2b2ae3d27d4eb4f
+	return h ^ h>>29
+}
+
+// searchShallow 残り数手の探索。置換表も並べ替えも使わず、スライスを確保せずにbitを直接辿る
+func (s *Solver) searchShallow(p uint64, o uint64, alpha int, beta int, passed bool) int {
+	s.nodes++
+	legal := game.LegalMoves(p, o)
+	if legal == 0 {
+		if passed {
+			return bits.OnesCount64(p) - bits.OnesCount64(o)
+		}
+		return -s.searchShallow(o, p, -beta, -alpha, true)
+	}
+
+	best := -maxDiff - 1
+	for legal != 0 {
+		m := legal & -legal
+		legal &^= m
+		f := game.Flips(p, o, m)
+		v := -s.searchShallow(o&^f, p|m|f, -beta, -alpha, false)
+		if v > best {
+			best = v
+		}
+		if v > alpha {
+			alpha = v
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return best
+}
+
+// orderMoves 空きマスが多いうちは相手の着手可能数が少なくなる手から調べる(速さ優先)。
+// 少なくなったら並べ替えのコストの方が大きいので、隅優先の静的な順序にする
+func (s *Solver) orderMoves(p uint64, o uint64, legal uint64) []uint64 {
+	moves := orderedMoves(legal)
+	if bits.OnesCount64(^(p|o)) < fastestFirstEmpties || len(moves) < 2 {
+		return moves
+	}
+
+	mobility := make([]int, len(moves))
+	for i, m := range moves {
+		f := game.Flips(p, o, m)
+		mobility[i] = bits.OnesCount64(game.LegalMoves(o&^f, p|m|f))
+	}
+	// 手の数は多くても20程度なので挿入ソートで十分
+	for i := 1; i < len(moves); i++ {
+		for j := i; j > 0 && mobility[j] < mobility[j-1]; j-- {
+			moves[j], moves[j-1] = moves[j-1], moves[j]
+			mobility[j], mobility[j-1] = mobility[j-1], mobility[j]
+		}
+	}
+	return moves
+}
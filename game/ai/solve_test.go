@@ -0,0 +1,151 @@
+package ai
+
+import (
+	"errors"
+	"math/bits"
+	"math/rand"
+	"testing"
+	"time"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+// minimax 置換表も枝刈りも使わずに、手番側から見た最終石差を求める。passedは直前の手番がパスだったか
+func minimax(p uint64, o uint64, passed bool) int {
+	legal := game.LegalMoves(p, o)
+	if legal == 0 {
+		if passed {
+			return bits.OnesCount64(p) - bits.OnesCount64(o)
+		}
+		return -minimax(o, p, true)
+	}
+	best := -maxDiff - 1
+	for legal != 0 {
+		m := legal & -legal
+		legal &^= m
+		f := game.Flips(p, o, m)
+		best = max(best, -minimax(o&^f, p|m|f, false))
+	}
+	return best
+}
+
+// endgamePosition 種から決まるランダムな対局を、空きマスがempties個になるまで打ち進めた局面と手番。
+// 終局してしまった場合は別の種で打ち直す
+func endgamePosition(seed int64, empties int) (*game.BitBoard, game.Character) {
+	for ; ; seed += 1000 {
+		rnd := rand.New(rand.NewSource(seed))
+		bb, c := game.NewBitBoard(), game.Black
+		for bb.Rest() > empties {
+			legal := bb.Legal(c)
+			if legal == 0 {
+				c = game.OpponentCharacter(c)
+				if legal = bb.Legal(c); legal == 0 {
+					break
+				}
+			}
+			var moves []uint64
+			for ; legal != 0; legal &= legal - 1 {
+				moves = append(moves, legal&-legal)
+			}
+			x, y := game.BitToCell(moves[rnd.Intn(len(moves))])
+			if err := bb.PutStone(x, y, c); err != nil {
+				panic(err)
+			}
+			c = game.OpponentCharacter(c)
+		}
+		if bb.Rest() == empties && (bb.Legal(c) != 0 || bb.Legal(game.OpponentCharacter(c)) != 0) {
+			if bb.Legal(c) == 0 {
+				c = game.OpponentCharacter(c)
+			}
+			return bb, c
+		}
+	}
+}
+
+// replay 最善進行を最後まで打ち、cから見た最終石差を返す。打てない手やパスできない場面でのパスはエラーにする
+func replay(t *testing.T, bb *game.BitBoard, c game.Character, moves []game.Ply) int {
+	t.Helper()
+	b := bb.Clone().(*game.BitBoard)
+	turn := c
+	for i, m := range moves {
+		if m.Character != turn {
+			t.Fatalf("ply %d: %v moved, want %v", i, m.Character, turn)
+		}
+		if m.Pass {
+			if b.Legal(turn) != 0 {
+				t.Fatalf("ply %d: %v passed with a legal move", i, turn)
+			}
+		} else if err := b.PutStone(m.X, m.Y, turn); err != nil {
+			t.Fatalf("ply %d: %v", i, err)
+		}
+		turn = game.OpponentCharacter(turn)
+	}
+	if b.Legal(game.Black) != 0 || b.Legal(game.White) != 0 {
+		t.Fatalf("the game has not ended after %d plies", len(moves))
+	}
+	return b.Score(c) - b.Score(game.OpponentCharacter(c))
+}
+
+// TestSolverMatchesMinimax 空きマス9個の局面で、完全読みの結果を素朴なミニマックスと比べる。
+// 置換表を使い回した場合も同じ結果になるよう、1つのSolverで全ての局面を読む
+func TestSolverMatchesMinimax(t *testing.T) {
+	s := NewSolver(0)
+	for seed := int64(1); seed <= 8; seed++ {
+		bb, c := endgamePosition(seed, 9)
+		p, o := bb.Stones(c), bb.Stones(game.OpponentCharacter(c))
+		want := minimax(p, o, false)
+
+		sol, err := s.Solve(bb, c)
+		if err != nil {
+			t.Fatalf("seed %d: Solve: %v", seed, err)
+		}
+		if sol.Diff != want {
+			t.Errorf("seed %d: Solve().Diff = %d, want %d", seed, sol.Diff, want)
+		}
+		if got := replay(t, bb, c, sol.Moves); got != sol.Diff {
+			t.Errorf("seed %d: replaying the principal variation ends at %d, want %d", seed, got, sol.Diff)
+		}
+
+		values, err := s.Values(bb, c)
+		if err != nil {
+			t.Fatalf("seed %d: Values: %v", seed, err)
+		}
+		if len(values) != bits.OnesCount64(game.LegalMoves(p, o)) {
+			t.Errorf("seed %d: %d values for %d legal moves", seed, len(values), bits.OnesCount64(game.LegalMoves(p, o)))
+		}
+		best := -maxDiff - 1
+		for _, v := range values {
+			m := game.Bit(v.X, v.Y)
+			f := game.Flips(p, o, m)
+			if w := -minimax(o&^f, p|m|f, false); v.Diff != w {
+				t.Errorf("seed %d: value of %v = %d, want %d", seed, game.PlyNotation(game.Ply{X: v.X, Y: v.Y}), v.Diff, w)
+			}
+			best = max(best, v.Diff)
+		}
+		if len(values) > 0 && best != want {
+			t.Errorf("seed %d: best value = %d, want %d", seed, best, want)
+		}
+	}
+}
+
+func TestSolverTimeout(t *testing.T) {
+	s := NewSolver(time.Nanosecond)
+	bb, c := endgamePosition(1, 20)
+	if _, err := s.Solve(bb, c); !errors.Is(err, ErrTimeout) {
+		t.Errorf("Solve = %v, want ErrTimeout", err)
+	}
+	if _, err := s.Values(bb, c); !errors.Is(err, ErrTimeout) {
+		t.Errorf("Values = %v, want ErrTimeout", err)
+	}
+
+	// 打ち切った後も、同じSolverで正しく読み切れる
+	s.TimeLimit = 0
+	small, c := endgamePosition(2, 9)
+	sol, err := s.Solve(small, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := minimax(small.Stones(c), small.Stones(game.OpponentCharacter(c)), false); sol.Diff != want {
+		t.Errorf("Solve after a timeout = %d, want %d", sol.Diff, want)
+	}
+}
@@ -0,0 +1,206 @@
+package game
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// BitBoard 盤面を黒と白それぞれの64bitで表現する。
+// セル(x, y)はbit (y-1)*8 + (x-1) に対応する。壁は持たず、範囲外はシフト時のマスクで判定する
+type BitBoard struct {
+	black uint64
+	white uint64
+}
+
+// 方向ごとのシフト量と、シフト後に端をまたいだbitを落とすためのマスク
+type direction struct {
+	shift int
+	mask  uint64
+}
+
+const (
+	notColA uint64 = 0xfefefefefefefefe // A列(x=1)以外
+	notColH uint64 = 0x7f7f7f7f7f7f7f7f // H列(x=8)以外
+	allCols uint64 = 0xffffffffffffffff
+)
+
+// 縦/横/斜めの8方向。右に1列ずれるシフトではA列に回り込んだbitを、左に1列ずれるシフトではH列に回り込んだbitを落とす
+var directions = [8]direction{
+	{shift: 1, mask: notColA},  // 右
+	{shift: -1, mask: notColH}, // 左
+	{shift: 8, mask: allCols},  // 下
+	{shift: -8, mask: allCols}, // 上
+	{shift: 9, mask: notColA},  // 右下
+	{shift: 7, mask: notColH},  // 左下
+	{shift: -7, mask: notColA}, // 右上
+	{shift: -9, mask: notColH}, // 左上
+}
+
+// NewBitBoard 初期石を置いた盤面を作成
+func NewBitBoard() *BitBoard {
+	b := &BitBoard{}
+	b.set(4, 4, White)
+	b.set(5, 5, White)
+	b.set(5, 4, Black)
+	b.set(4, 5, Black)
+	return b
+}
+
+// NewBitBoardFrom 任意の盤面からBitBoardを作成する
+func NewBitBoardFrom(src Boarder) *BitBoard {
+	if bb, ok := src.(*BitBoard); ok {
+		return bb.Clone().(*BitBoard)
+	}
+	b := &BitBoard{}
+	for x := int32(1); x <= BoardSize; x++ {
+		for y := int32(1); y <= BoardSize; y++ {
+			b.set(x, y, src.Cell(x, y))
+		}
+	}
+	return b
+}
+
+// Bit セル(x, y)に対応するbit
+func Bit(x int32, y int32) uint64 {
+	return 1 << uint((y-1)*BoardSize+(x-1))
+}
+
+// BitToCell bitからセル(x, y)に変換する。bitは1つだけ立っている前提
+func BitToCell(m uint64) (int32, int32) {
+	i := int32(bits.TrailingZeros64(m))
+	return i%BoardSize + 1, i/BoardSize + 1
+}
+
+func shift(b uint64, d direction) uint64 {
+	if d.shift > 0 {
+		return (b << uint(d.shift)) & d.mask
+	}
+	return (b >> uint(-d.shift)) & d.mask
+}
+
+// LegalMoves 自分の石p、相手の石oのとき、自分が置けるセルのbit集合
+func LegalMoves(p uint64, o uint64) uint64 {
+	empty := ^(p | o)
+	var moves uint64
+	for _, d := range directions {
+		// 自分の石から相手の石が連続する範囲を伸ばしていき、その先が空いていれば置ける
+		t := shift(p, d) & o
+		for i := 0; i < BoardSize-3; i++ {
+			t |= shift(t, d) & o
+		}
+		moves |= shift(t, d) & empty
+	}
+	return moves
+}
+
+// Flips 自分の石p、相手の石oのとき、mに置いた場合にひっくり返る相手の石のbit集合
+func Flips(p uint64, o uint64, m uint64) uint64 {
+	var flips uint64
+	for _, d := range directions {
+		var f uint64
+		x := shift(m, d)
+		for x&o != 0 {
+			f |= x
+			x = shift(x, d)
+		}
+		// 相手の石の先に自分の石があれば挟めている
+		if x&p != 0 {
+			flips |= f
+		}
+	}
+	return flips
+}
+
+// Stones その色の石のbit集合
+func (b *BitBoard) Stones(c Character) uint64 {
+	switch c {
+	case Black:
+		return b.black
+	case White:
+		return b.white
+	}
+	return 0
+}
+
+// Legal その色が置けるセルのbit集合
+func (b *BitBoard) Legal(c Character) uint64 {
+	return LegalMoves(b.Stones(c), b.Stones(OpponentCharacter(c)))
+}
+
+func (b *BitBoard) set(x int32, y int32, c Character) {
+	m := Bit(x, y)
+	b.black &^= m
+	b.white &^= m
+	switch c {
+	case Black:
+		b.black |= m
+	case White:
+		b.white |= m
+	}
+}
+
+func (b *BitBoard) PutStone(x int32, y int32, c Character) error {
+	// セルに石を置けるかチェック
+	if !b.CanPutStone(x, y, c) {
+		return fmt.Errorf("%w: can not put stone x=%v, y=%v color=%v", ErrIllegalMove, x, y, CharacterToStr(c))
+	}
+
+	m := Bit(x, y)
+	p, o := b.Stones(c), b.Stones(OpponentCharacter(c))
+	f := Flips(p, o, m)
+	p |= m | f
+	o &^= f
+	if c == Black {
+		b.black, b.white = p, o
+	} else {
+		b.white, b.black = p, o
+	}
+	return nil
+}
+
+func (b *BitBoard) CanPutStone(x int32, y int32, c Character) bool {
+	// 壁や範囲外には置けない
+	if x < 1 || BoardSize < x || y < 1 || BoardSize < y {
+		return false
+	}
+	if c != Black && c != White {
+		return false
+	}
+	return b.Legal(c)&Bit(x, y) != 0
+}
+
+// AvailableCellCount 盤面内で、「ある色の石」をおけるセルの数を数える
+func (b *BitBoard) AvailableCellCount(c Character) int {
+	return bits.OnesCount64(b.Legal(c))
+}
+
+// Score 盤面内に置かれている石の数
+func (b *BitBoard) Score(c Character) int {
+	return bits.OnesCount64(b.Stones(c))
+}
+
+// Rest 盤面ないで石が置かれていないセルの数
+func (b *BitBoard) Rest() int {
+	return BoardSize*BoardSize - bits.OnesCount64(b.black|b.white)
+}
+
+// Cell そのセルの状態。範囲外は壁とする
+func (b *BitBoard) Cell(x int32, y int32) Character {
+	if x < 1 || BoardSize < x || y < 1 || BoardSize < y {
+		return Wall
+	}
+	m := Bit(x, y)
+	switch {
+	case b.black&m != 0:
+		return Black
+	case b.white&m != 0:
+		return White
+	}
+	return Empty
+}
+
+// Clone 盤面を複製する
+func (b *BitBoard) Clone() Boarder {
+	c := *b
+	return &c
+}
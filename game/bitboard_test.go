@@ -0,0 +1,160 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// TestBitBoardMatchesBoard ランダムに打ち進めた対局で、BitBoardとBoardが毎手同じ結果になるかを確かめる
+func TestBitBoardMatchesBoard(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		board, bb := NewBoard(), NewBitBoard()
+		c := Black
+		for ply := 0; ; ply++ {
+			compareBoards(t, board, bb)
+			if t.Failed() {
+				t.Fatalf("game %d diverged after %d plies", i, ply)
+			}
+			if board.AvailableCellCount(c) == 0 {
+				c = OpponentCharacter(c)
+				if board.AvailableCellCount(c) == 0 {
+					break
+				}
+			}
+
+			// 置けない場所に置こうとした場合は、どちらもエラーにして盤面を変えない
+			x, y := int32(rnd.Intn(BoardSize)+1), int32(rnd.Intn(BoardSize)+1)
+			if !board.CanPutStone(x, y, c) {
+				errBoard, errBB := board.PutStone(x, y, c), bb.PutStone(x, y, c)
+				if !errors.Is(errBoard, ErrIllegalMove) || !errors.Is(errBB, ErrIllegalMove) {
+					t.Fatalf("PutStone(%d, %d, %v) on an illegal cell: Board=%v BitBoard=%v", x, y, c, errBoard, errBB)
+				}
+				compareBoards(t, board, bb)
+			}
+
+			moves := legalCells(board, c)
+			m := moves[rnd.Intn(len(moves))]
+			if err := board.PutStone(m[0], m[1], c); err != nil {
+				t.Fatalf("Board.PutStone(%d, %d, %v): %v", m[0], m[1], c, err)
+			}
+			if err := bb.PutStone(m[0], m[1], c); err != nil {
+				t.Fatalf("BitBoard.PutStone(%d, %d, %v): %v", m[0], m[1], c, err)
+			}
+			c = OpponentCharacter(c)
+		}
+	}
+}
+
+// compareBoards 全てのセル、置ける場所、置ける数、石の数、空きマスの数を比べる
+func compareBoards(t *testing.T, board *Board, bb *BitBoard) {
+	t.Helper()
+	for x := int32(0); x <= BoardSize+1; x++ {
+		for y := int32(0); y <= BoardSize+1; y++ {
+			if a, b := board.Cell(x, y), bb.Cell(x, y); a != b {
+				t.Errorf("Cell(%d, %d): Board=%v BitBoard=%v", x, y, a, b)
+			}
+			for _, c := range []Character{Black, White} {
+				if a, b := board.CanPutStone(x, y, c), bb.CanPutStone(x, y, c); a != b {
+					t.Errorf("CanPutStone(%d, %d, %v): Board=%v BitBoard=%v", x, y, c, a, b)
+				}
+			}
+		}
+	}
+	for _, c := range []Character{Black, White} {
+		if a, b := board.AvailableCellCount(c), bb.AvailableCellCount(c); a != b {
+			t.Errorf("AvailableCellCount(%v): Board=%d BitBoard=%d", c, a, b)
+		}
+		if a, b := board.Score(c), bb.Score(c); a != b {
+			t.Errorf("Score(%v): Board=%d BitBoard=%d", c, a, b)
+		}
+	}
+	if a, b := board.Rest(), bb.Rest(); a != b {
+		t.Errorf("Rest(): Board=%d BitBoard=%d", a, b)
+	}
+}
+
+// legalCells cの手番で置けるセル
+func legalCells(b Boarder, c Character) [][2]int32 {
+	var cells [][2]int32
+	for x := int32(1); x <= BoardSize; x++ {
+		for y := int32(1); y <= BoardSize; y++ {
+			if b.CanPutStone(x, y, c) {
+				cells = append(cells, [2]int32{x, y})
+			}
+		}
+	}
+	return cells
+}
+
+// perftCounts 初期局面から各深さまで打ち進めた局面の数。パスも1手と数える
+var perftCounts = []int{1, 4, 12, 56, 244, 1396, 8200, 55092}
+
+func TestPerft(t *testing.T) {
+	for name, b := range map[string]Boarder{
+		"Board":    NewBoard(),
+		"BitBoard": NewBitBoard(),
+	} {
+		for depth, want := range perftCounts[:7] {
+			if got := perft(b, Black, depth, false); got != want {
+				t.Errorf("%v: perft(%d) = %d, want %d", name, depth, got, want)
+			}
+		}
+	}
+}
+
+func TestBitBoardPerft(t *testing.T) {
+	bb := NewBitBoard()
+	for depth, want := range perftCounts {
+		if got := perftBits(bb.Stones(Black), bb.Stones(White), depth, false); got != want {
+			t.Errorf("perft(%d) = %d, want %d", depth, got, want)
+		}
+	}
+}
+
+// perft Boarderのメソッドだけで、cの手番からdepth手打ち進めた局面を数える。passedは直前の手番がパスだったか
+func perft(b Boarder, c Character, depth int, passed bool) int {
+	if depth == 0 {
+		return 1
+	}
+	moves := legalCells(b, c)
+	if len(moves) == 0 {
+		if passed {
+			// 双方置けないので終局
+			return 1
+		}
+		return perft(b, OpponentCharacter(c), depth-1, true)
+	}
+	n := 0
+	for _, m := range moves {
+		next := b.Clone()
+		if err := next.PutStone(m[0], m[1], c); err != nil {
+			panic(err)
+		}
+		n += perft(next, OpponentCharacter(c), depth-1, false)
+	}
+	return n
+}
+
+// perftBits LegalMovesとFlipsで、手番側pからdepth手打ち進めた局面を数える
+func perftBits(p uint64, o uint64, depth int, passed bool) int {
+	if depth == 0 {
+		return 1
+	}
+	legal := LegalMoves(p, o)
+	if legal == 0 {
+		if passed {
+			return 1
+		}
+		return perftBits(o, p, depth-1, true)
+	}
+	n := 0
+	for legal != 0 {
+		m := legal & -legal
+		legal &^= m
+		f := Flips(p, o, m)
+		n += perftBits(o&^f, p|m|f, depth-1, false)
+	}
+	return n
+}
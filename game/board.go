@@ -2,6 +2,22 @@ package game
 
 import "fmt"
 
+// Boarder 盤面の共通インターフェース。Game、DTO、ハンドラはこれを通して盤面を扱うので、実装を差し替えられる
+// 座標は壁を含めた(x, y)で、石を置けるのは1 <= x, y <= 8。xが列(A-H)、yが行(1-8)
+type Boarder interface {
+	PutStone(x int32, y int32, c Character) error
+	CanPutStone(x int32, y int32, c Character) bool
+	AvailableCellCount(c Character) int
+	Score(c Character) int
+	Rest() int
+	// Cell そのセルの状態。壁の位置(0, 9)ではWallを返す
+	Cell(x int32, y int32) Character
+	Clone() Boarder
+}
+
+// BoardSize 壁を含まない盤面の一辺のセル数
+const BoardSize = 8
+
 // Board 盤面を8×8のセルとそれを囲む壁で表現する。そのため10×10の二次元配列となる(壁は上下左右で1列ずつなので、xとyは2ずつ引いて8×8)
 type Board struct {
 	// セルを定義。
@@ -35,8 +51,8 @@ func NewBoard() *Board {
 		b.Cells[i][wallThresholdNum] = Wall
 	}
 
-	// 右の壁。左上下の壁作成時に(0,9), (9,9)は作ってあるので1 <= i < 9
-	for i := 0; i < wallThresholdNum; i++ {
+	// 右の壁。(9,0)と(9,9)の角も壁にする必要があるので0 <= i < 10
+	for i := 0; i < cellNum; i++ {
 		b.Cells[wallThresholdNum][i] = Wall
 	}
 
@@ -49,10 +65,24 @@ func NewBoard() *Board {
 	return b
 }
 
+// Cell そのセルの状態
+func (b *Board) Cell(x int32, y int32) Character {
+	return b.Cells[x][y]
+}
+
+// Clone 盤面を複製する
+func (b *Board) Clone() Boarder {
+	cells := make([][]Character, len(b.Cells))
+	for i, col := range b.Cells {
+		cells[i] = append([]Character(nil), col...)
+	}
+	return &Board{Cells: cells}
+}
+
 func (b *Board) PutStone(x int32, y int32, c Character) error {
 	// セルに石を置けるかチェック
 	if !b.CanPutStone(x, y, c) {
-		return fmt.Errorf("can not put stone x=%v, y=%v color=%v", x, y, CharacterToStr(c))
+		return fmt.Errorf("%w: can not put stone x=%v, y=%v color=%v", ErrIllegalMove, x, y, CharacterToStr(c))
 	}
 
 	b.Cells[x][y] = c
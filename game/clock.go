@@ -0,0 +1,158 @@
+package game
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidTimeControl 持ち時間の設定が不正
+var ErrInvalidTimeControl = errors.New("invalid time control")
+
+// TimeControlKind 持ち時間の方式
+type TimeControlKind int
+
+const (
+	// NoTimeControl 持ち時間なし
+	NoTimeControl TimeControlKind = iota
+	// Absolute 切れ負け。持ち時間を使い切ったら負け
+	Absolute
+	// Fischer フィッシャールール。1手打つごとにIncrementが持ち時間に加算される
+	Fischer
+	// Byoyomi 秒読み。持ち時間を使い切った後は、1手ごとにByoyomiの時間内に打てば良い
+	Byoyomi
+)
+
+// TimeControl 部屋ごとの持ち時間の設定
+type TimeControl struct {
+	Kind      TimeControlKind
+	Main      time.Duration // 持ち時間
+	Increment time.Duration // Fischerで1手ごとに加算される時間
+	Byoyomi   time.Duration // Byoyomiで1手あたりに使える時間
+}
+
+// Enabled 持ち時間があるか
+func (tc TimeControl) Enabled() bool {
+	return tc.Kind != NoTimeControl
+}
+
+// Validate 設定が方式に合っているかを確認する
+func (tc TimeControl) Validate() error {
+	switch tc.Kind {
+	case NoTimeControl:
+		return nil
+	case Absolute:
+		if tc.Main <= 0 {
+			return ErrInvalidTimeControl
+		}
+	case Fischer:
+		if tc.Main <= 0 || tc.Increment < 0 {
+			return ErrInvalidTimeControl
+		}
+	case Byoyomi:
+		// 持ち時間なしで最初から秒読みでも良い
+		if tc.Main < 0 || tc.Byoyomi <= 0 {
+			return ErrInvalidTimeControl
+		}
+	default:
+		return ErrInvalidTimeControl
+	}
+	return nil
+}
+
+// Clock 対局時計。手番の色の時計だけが進む
+type Clock struct {
+	tc        TimeControl
+	remaining map[Character]time.Duration // 止まっている時点での持ち時間の残り
+	running   Character                   // 時計が進んでいる色。止まっていればNone
+	since     time.Time                   // runningの時計が進み始めた時刻
+	now       func() time.Time
+}
+
+func NewClock(tc TimeControl) *Clock {
+	return &Clock{
+		tc: tc,
+		remaining: map[Character]time.Duration{
+			Black: tc.Main,
+			White: tc.Main,
+		},
+		running: None,
+		now:     time.Now,
+	}
+}
+
+// TimeControl 時計の持ち時間の設定
+func (c *Clock) TimeControl() TimeControl {
+	return c.tc
+}
+
+// SetRemaining chの持ち時間の残りを設定する。保存しておいた対局を再開する場合に使う
+func (c *Clock) SetRemaining(ch Character, d time.Duration) {
+	c.remaining[ch] = d
+}
+
+// Running 時計が進んでいる色。止まっていればNone
+func (c *Clock) Running() Character {
+	return c.running
+}
+
+// Run chの時計を進める。他の色の時計が進んでいれば、加算なしで止める
+func (c *Clock) Run(ch Character) {
+	c.Stop()
+	c.running = ch
+	c.since = c.now()
+}
+
+// Stop 進んでいる時計を加算なしで止める。待ったなど、手を打たずに手番が変わる場合に使う
+func (c *Clock) Stop() {
+	if c.running == None {
+		return
+	}
+	c.remaining[c.running] = c.charge(c.running)
+	c.running = None
+}
+
+// Press 手を打ったので時計を止める。Fischerでは持ち時間が加算され、Byoyomiでは秒読みがリセットされる
+func (c *Clock) Press() {
+	ch := c.running
+	if ch == None {
+		return
+	}
+	c.Stop()
+	if c.tc.Kind == Fischer {
+		c.remaining[ch] += c.tc.Increment
+	}
+}
+
+// Remaining chの残り時間。秒読みに入っている場合は、その手に使える残り時間と、秒読み中であることを返す
+func (c *Clock) Remaining(ch Character) (time.Duration, bool) {
+	main := c.remaining[ch]
+	if ch == c.running {
+		main -= c.now().Sub(c.since)
+	}
+	if c.tc.Kind != Byoyomi || main > 0 {
+		return max(main, 0), false
+	}
+	// 持ち時間を超えた分を秒読みから引く
+	return max(c.tc.Byoyomi+main, 0), true
+}
+
+// Flagged 時計が進んでいる色が時間切れになっていればその色を返す。なければNone
+func (c *Clock) Flagged() Character {
+	if c.running == None || !c.tc.Enabled() {
+		return None
+	}
+	if left, _ := c.Remaining(c.running); left <= 0 {
+		return c.running
+	}
+	return None
+}
+
+// charge 進んでいる時計で使った時間を引いた、持ち時間の残り
+func (c *Clock) charge(ch Character) time.Duration {
+	left := c.remaining[ch] - c.now().Sub(c.since)
+	if c.tc.Kind == Byoyomi && left < 0 {
+		// 秒読みの時間内に打っていれば、持ち時間はゼロのまま
+		return 0
+	}
+	return left
+}
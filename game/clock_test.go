@@ -0,0 +1,171 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+// testClock 進めた分だけ時刻が進む時計。黒の時計を動かした状態で返す
+func testClock(tc TimeControl) (*Clock, func(time.Duration)) {
+	now := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	c := NewClock(tc)
+	c.now = func() time.Time { return now }
+	c.Run(Black)
+	return c, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestClockSingleMove(t *testing.T) {
+	absolute := TimeControl{Kind: Absolute, Main: time.Minute}
+	fischer := TimeControl{Kind: Fischer, Main: time.Minute, Increment: 5 * time.Second}
+	byoyomi := TimeControl{Kind: Byoyomi, Main: time.Minute, Byoyomi: 10 * time.Second}
+	tests := []struct {
+		name        string
+		tc          TimeControl
+		elapsed     time.Duration // 黒が考えた時間
+		press       bool          // 考えた後に手を打ったか
+		wantLeft    time.Duration
+		wantByoyomi bool
+		wantFlagged Character
+	}{
+		{"absolute thinking", absolute, 30 * time.Second, false, 30 * time.Second, false, None},
+		{"absolute pressed", absolute, 30 * time.Second, true, 30 * time.Second, false, None},
+		{"absolute just before expiry", absolute, time.Minute - time.Nanosecond, false, time.Nanosecond, false, None},
+		// 持ち時間を使い切った時刻ちょうどで時間切れ
+		{"absolute at expiry", absolute, time.Minute, false, 0, false, Black},
+		{"absolute overrun", absolute, 2 * time.Minute, false, 0, false, Black},
+
+		{"fischer thinking", fischer, 30 * time.Second, false, 30 * time.Second, false, None},
+		{"fischer pressed", fischer, 30 * time.Second, true, 35 * time.Second, false, None},
+		{"fischer pressed before expiry", fischer, time.Minute - time.Second, true, 6 * time.Second, false, None},
+		{"fischer at expiry", fischer, time.Minute, false, 0, false, Black},
+
+		{"byoyomi main time", byoyomi, 30 * time.Second, false, 30 * time.Second, false, None},
+		// 持ち時間を使い切った時点で秒読みに入り、まだ時間切れではない
+		{"byoyomi enters byoyomi", byoyomi, time.Minute, false, 10 * time.Second, true, None},
+		{"byoyomi counting", byoyomi, time.Minute + 4*time.Second, false, 6 * time.Second, true, None},
+		{"byoyomi at expiry", byoyomi, time.Minute + 10*time.Second, false, 0, true, Black},
+		// 秒読みの途中で打てば、次の手はまた秒読みの全部を使える
+		{"byoyomi pressed", byoyomi, time.Minute + 9*time.Second, true, 10 * time.Second, true, None},
+		{"byoyomi without main time", TimeControl{Kind: Byoyomi, Byoyomi: 10 * time.Second}, 3 * time.Second, false, 7 * time.Second, true, None},
+
+		{"no time control", TimeControl{}, time.Hour, false, 0, false, None},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, advance := testClock(tt.tc)
+			white, _ := c.Remaining(White)
+			advance(tt.elapsed)
+			if tt.press {
+				c.Press()
+			}
+			left, byoyomi := c.Remaining(Black)
+			if left != tt.wantLeft || byoyomi != tt.wantByoyomi {
+				t.Errorf("Remaining(Black) = %v, %v, want %v, %v", left, byoyomi, tt.wantLeft, tt.wantByoyomi)
+			}
+			if got := c.Flagged(); got != tt.wantFlagged {
+				t.Errorf("Flagged = %v, want %v", got, tt.wantFlagged)
+			}
+			// 白の時計は進んでいない
+			if left, _ := c.Remaining(White); left != white {
+				t.Errorf("Remaining(White) = %v, want %v", left, white)
+			}
+		})
+	}
+}
+
+// TestClockMoves 何手か続けて打ち、持ち時間の加算や秒読みのリセットが積み重なることを確かめる
+func TestClockMoves(t *testing.T) {
+	tests := []struct {
+		name        string
+		tc          TimeControl
+		moves       []time.Duration // 黒が1手ごとに考えた時間。白は毎回1秒で打つ
+		thinking    time.Duration   // 最後に黒が考えている時間
+		wantLeft    time.Duration
+		wantByoyomi bool
+		wantFlagged Character
+	}{
+		{
+			name:        "fischer accumulates increments",
+			tc:          TimeControl{Kind: Fischer, Main: time.Minute, Increment: 5 * time.Second},
+			moves:       []time.Duration{2 * time.Second, 2 * time.Second, 2 * time.Second},
+			wantLeft:    time.Minute + 9*time.Second,
+			wantFlagged: None,
+		},
+		{
+			// 加算された時間を使い切った時刻ちょうどで時間切れ
+			name:        "fischer expires after increments",
+			tc:          TimeControl{Kind: Fischer, Main: 10 * time.Second, Increment: 5 * time.Second},
+			moves:       []time.Duration{9 * time.Second, 5 * time.Second},
+			thinking:    6 * time.Second,
+			wantLeft:    0,
+			wantFlagged: Black,
+		},
+		{
+			name:        "absolute has no increment",
+			tc:          TimeControl{Kind: Absolute, Main: time.Minute},
+			moves:       []time.Duration{10 * time.Second, 10 * time.Second},
+			thinking:    5 * time.Second,
+			wantLeft:    35 * time.Second,
+			wantFlagged: None,
+		},
+		{
+			name:        "byoyomi resets every move",
+			tc:          TimeControl{Kind: Byoyomi, Main: 20 * time.Second, Byoyomi: 10 * time.Second},
+			moves:       []time.Duration{25 * time.Second, 9 * time.Second, 9 * time.Second},
+			thinking:    3 * time.Second,
+			wantLeft:    7 * time.Second,
+			wantByoyomi: true,
+			wantFlagged: None,
+		},
+		{
+			name:        "byoyomi expires within a period",
+			tc:          TimeControl{Kind: Byoyomi, Main: 20 * time.Second, Byoyomi: 10 * time.Second},
+			moves:       []time.Duration{25 * time.Second},
+			thinking:    10 * time.Second,
+			wantLeft:    0,
+			wantByoyomi: true,
+			wantFlagged: Black,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, advance := testClock(tt.tc)
+			for _, d := range tt.moves {
+				advance(d)
+				if got := c.Flagged(); got != None {
+					t.Fatalf("%v flagged before pressing", got)
+				}
+				c.Press()
+				c.Run(White)
+				advance(time.Second)
+				c.Press()
+				c.Run(Black)
+			}
+			advance(tt.thinking)
+			left, byoyomi := c.Remaining(Black)
+			if left != tt.wantLeft || byoyomi != tt.wantByoyomi {
+				t.Errorf("Remaining(Black) = %v, %v, want %v, %v", left, byoyomi, tt.wantLeft, tt.wantByoyomi)
+			}
+			if got := c.Flagged(); got != tt.wantFlagged {
+				t.Errorf("Flagged = %v, want %v", got, tt.wantFlagged)
+			}
+		})
+	}
+}
+
+// TestClockStop 待ったなどで止めた時計には、持ち時間を加算しない
+func TestClockStop(t *testing.T) {
+	c, advance := testClock(TimeControl{Kind: Fischer, Main: time.Minute, Increment: 5 * time.Second})
+	advance(10 * time.Second)
+	c.Stop()
+	if c.Running() != None {
+		t.Fatalf("Running = %v after Stop, want None", c.Running())
+	}
+	advance(time.Hour)
+	if left, _ := c.Remaining(Black); left != 50*time.Second {
+		t.Errorf("Remaining(Black) = %v, want 50s", left)
+	}
+	if got := c.Flagged(); got != None {
+		t.Errorf("Flagged = %v on a stopped clock, want None", got)
+	}
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+)
+
+// serverSeedSize サーバーの種のバイト数
+const serverSeedSize = 32
+
+// NewServerSeed サーバーの種を作る。16進数の文字列で、抽選が終わるまではハッシュだけを公開する
+func NewServerSeed() (string, error) {
+	b := make([]byte, serverSeedSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashSeed サーバーの種のSHA-256を16進数の文字列にする。先に公開しておき、後で明かした種と一致するかを確かめる
+func HashSeed(serverSeed string) string {
+	sum := sha256.Sum256([]byte(serverSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifySeed 明かされたサーバーの種が、先に公開されていたハッシュと一致するか
+func VerifySeed(serverSeed, hash string) bool {
+	return hmac.Equal([]byte(HashSeed(serverSeed)), []byte(hash))
+}
+
+// FairRandom サーバーの種、プレイヤーが決めた種、何回目の抽選かから決まる乱数。
+// HMAC-SHA256(サーバーの種, "プレイヤーの種:抽選の番号:何個目の乱数")の先頭8バイトを符号なし整数として使う。
+// 偏りが出ないよう、nで割り切れない端の値は捨てて次の乱数を使う。
+// サーバーの種を明かした後は、プレイヤーが同じ計算をして抽選をやり直せる
+type FairRandom struct {
+	serverSeed string
+	clientSeed string
+	nonce      int64
+	round      int
+}
+
+func NewFairRandom(serverSeed, clientSeed string, nonce int64) *FairRandom {
+	return &FairRandom{
+		serverSeed: serverSeed,
+		clientSeed: clientSeed,
+		nonce:      nonce,
+	}
+}
+
+func (r *FairRandom) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	for {
+		if v, ok := reduce(r.next(), uint64(n)); ok {
+			return v
+		}
+	}
+}
+
+// reduce 64ビットの乱数vをn未満の値にする。nで割り切れない端の値で偏りが出る場合はfalse
+func reduce(v, n uint64) (int, bool) {
+	// 受け付ける値の数を2^64以下で最大のnの倍数にする
+	limit := math.MaxUint64 - (math.MaxUint64%n+1)%n
+	if v > limit {
+		return 0, false
+	}
+	return int(v % n), true
+}
+
+// next 次の64ビットの乱数
+func (r *FairRandom) next() uint64 {
+	mac := hmac.New(sha256.New, []byte(r.serverSeed))
+	fmt.Fprintf(mac, "%s:%d:%d", r.clientSeed, r.nonce, r.round)
+	r.round++
+	return binary.BigEndian.Uint64(mac.Sum(nil))
+}
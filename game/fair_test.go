@@ -0,0 +1,88 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFairRandomIsDeterministic(t *testing.T) {
+	a := NewFairRandom("server", "client", 3)
+	b := NewFairRandom("server", "client", 3)
+	for i := 0; i < 100; i++ {
+		if x, y := a.Intn(1000), b.Intn(1000); x != y {
+			t.Fatalf("draw %d: got %d and %d from the same seeds", i, x, y)
+		}
+	}
+}
+
+func TestFairRandomDependsOnSeeds(t *testing.T) {
+	sequence := func(r *FairRandom) []int {
+		s := make([]int, 10)
+		for i := range s {
+			s[i] = r.Intn(1 << 30)
+		}
+		return s
+	}
+	base := sequence(NewFairRandom("server", "client", 0))
+	for name, r := range map[string]*FairRandom{
+		"server seed": NewFairRandom("other", "client", 0),
+		"client seed": NewFairRandom("server", "other", 0),
+		"nonce":       NewFairRandom("server", "client", 1),
+	} {
+		if equal(base, sequence(r)) {
+			t.Errorf("changing the %v did not change the draws", name)
+		}
+	}
+}
+
+func equal(a, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestVerifySeed(t *testing.T) {
+	seed, err := NewServerSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := HashSeed(seed)
+	if !VerifySeed(seed, hash) {
+		t.Errorf("VerifySeed(%q, %q) = false, want true", seed, hash)
+	}
+	other, err := NewServerSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if VerifySeed(other, hash) {
+		t.Errorf("VerifySeed accepted a different seed for hash %q", hash)
+	}
+}
+
+func TestReduceRejectsBiasedValues(t *testing.T) {
+	tests := []struct {
+		v, n uint64
+		want int
+		ok   bool
+	}{
+		// 2^64は3で割ると1余るので、最大値だけを捨てる
+		{v: math.MaxUint64, n: 3, ok: false},
+		{v: math.MaxUint64 - 1, n: 3, want: int((math.MaxUint64 - 1) % 3), ok: true},
+		// 2^64は6で割ると4余るので、上から4つを捨てる
+		{v: math.MaxUint64 - 3, n: 6, ok: false},
+		{v: math.MaxUint64 - 4, n: 6, want: int((math.MaxUint64 - 4) % 6), ok: true},
+		// 2の累乗なら偏らないので全て受け付ける
+		{v: math.MaxUint64, n: 8, want: 7, ok: true},
+		{v: 0, n: 1, want: 0, ok: true},
+		{v: 10, n: 3, want: 1, ok: true},
+	}
+	for _, tt := range tests {
+		got, ok := reduce(tt.v, tt.n)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("reduce(%d, %d) = %d, %v; want %d, %v", tt.v, tt.n, got, ok, tt.want, tt.ok)
+		}
+	}
+}
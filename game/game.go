@@ -1,43 +1,134 @@
 package game
 
-import "fmt"
+import "errors"
+
+var (
+	// ErrNotYourTurn 手番ではないプレイヤーが手を打とうとした
+	ErrNotYourTurn = errors.New("not your turn")
+	// ErrSeatTaken すでに別のプレイヤーが座っている色に座ろうとした
+	ErrSeatTaken = errors.New("seat already taken")
+	// ErrInvalidCharacter 黒白以外の色で参加しようとした
+	ErrInvalidCharacter = errors.New("invalid character")
+	// ErrCannotPass 置ける場所があるのにパスしようとした
+	ErrCannotPass = errors.New("can not pass")
+	// ErrIllegalMove 石を置けない場所に置こうとした
+	ErrIllegalMove = errors.New("illegal move")
+)
 
 type Game struct {
-	Board    *Board
-	started  bool
-	finished bool
-	me       Character
+	Board       Boarder
+	started     bool
+	finished    bool
+	me          Character
+	turn        Character             // 手番
+	seats       map[Character]*Player // 色ごとの着席プレイヤー
+	history     []Ply                 // 打たれた手とパスの記録。待ったで戻した手もやり直せるように残す
+	cursor      int                   // 盤面に反映されているhistoryの手数
+	initial     Boarder               // 初期盤面。待ったの際はここから打ち直す
+	termination Termination           // 対局の終わり方
+	loser       Character             // 投了や時間切れ、切断で負けた色。なければNone
 }
 
 func NewGame(me Character) *Game {
+	return NewGameWithBoard(me, NewBoard())
+}
+
+// NewGameWithBoard 盤面の実装を指定してゲームを作成する
+func NewGameWithBoard(me Character, b Boarder) *Game {
 	return &Game{
-		Board: NewBoard(),
-		me:    me,
+		Board:   b,
+		initial: b.Clone(),
+		me:      me,
+		turn:    Black, // 黒が先手
+		seats:   make(map[Character]*Player, 2),
+		loser:   None,
+	}
+}
+
+// Sit プレイヤーをその色の席に座らせる。各色の席には一人しか座れない
+func (g *Game) Sit(p *Player) error {
+	if p.Character != Black && p.Character != White {
+		return ErrInvalidCharacter
+	}
+	if _, ok := g.seats[p.Character]; ok {
+		return ErrSeatTaken
 	}
+	g.seats[p.Character] = p
+	return nil
+}
+
+// Seated その色の席に座っているプレイヤー。いなければnil
+func (g *Game) Seated(c Character) *Player {
+	return g.seats[c]
+}
+
+// Start 対局者が揃ったので対局を開始する
+func (g *Game) Start() {
+	g.started = true
+}
+
+// Started 対局が開始しているか
+func (g *Game) Started() bool {
+	return g.started
+}
+
+// Finished ゲームが終了しているか
+func (g *Game) Finished() bool {
+	return g.finished
 }
 
-// Move 手を打ち、その後盤面を出力する
+// Turn 現在の手番の色
+func (g *Game) Turn() Character {
+	return g.turn
+}
+
+// Move 手を打つ。盤面の表示は呼び出し元でRendererを使って行う
 // 返り値として、ゲームが終了したかを返却
 // TODO: Progressなどに命名変更するべき
 func (g *Game) Move(x int32, y int32, c Character) (bool, error) {
 	if g.finished {
 		return true, nil
 	}
+	// 手番でない色の手は受け付けない
+	if c != g.turn {
+		return false, ErrNotYourTurn
+	}
 	// g.Boardに石をおくメソッド
 	err := g.Board.PutStone(x, y, c)
 	if err != nil {
 		return false, err
 	}
-	// TODO: この引数いる？？
-	g.Display()
+	g.turn = OpponentCharacter(c)
+	g.record(Ply{Character: c, X: x, Y: y})
 	if g.IsGameOver() {
-		fmt.Println("finished")
-		g.finished = true
+		g.finish(Completed, None)
 		return true, nil
 	}
 	return false, nil
 }
 
+// MustPass 手番の色がどこにも石を置けず、パスしなければならないかを判定
+// 双方置けない場合はゲーム終了なのでパスではない
+func (g *Game) MustPass() bool {
+	if g.finished {
+		return false
+	}
+	return g.Board.AvailableCellCount(g.turn) == 0 && g.Board.AvailableCellCount(OpponentCharacter(g.turn)) > 0
+}
+
+// Pass 手番をパスして相手に手番を渡す。置ける場所がある場合はパスできない
+func (g *Game) Pass(c Character) error {
+	if c != g.turn {
+		return ErrNotYourTurn
+	}
+	if !g.MustPass() {
+		return ErrCannotPass
+	}
+	g.turn = OpponentCharacter(c)
+	g.record(Ply{Character: c, Pass: true})
+	return nil
+}
+
 // IsGameOver ゲームが終了したかを判定
 // 黒と白双方における場所がなければ終了とする
 func (g *Game) IsGameOver() bool {
@@ -48,7 +139,14 @@ func (g *Game) IsGameOver() bool {
 }
 
 // Winner 勝者の色を返却。引き分けの場合はNone
+// 投了や時間切れで終了した場合は石の数に関係なく負けた色の相手、合意の引き分けや中止の場合はNone
 func (g *Game) Winner() Character {
+	switch g.termination {
+	case Resigned, TimeForfeit:
+		return OpponentCharacter(g.loser)
+	case DrawAgreed, Aborted:
+		return None
+	}
 	black := g.Board.Score(Black)
 	white := g.Board.Score(White)
 	if black == white {
@@ -58,42 +156,3 @@ func (g *Game) Winner() Character {
 	}
 	return White
 }
-
-// Display 盤面を出力
-func (g *Game) Display() {
-	fmt.Println("")
-	if g.me != None {
-		fmt.Printf("You: %v\n", CharacterToStr(g.me))
-	}
-
-	fmt.Print(" ｜ ")
-	rs := []rune("ABCDEFGH")
-	for i, r := range rs {
-		fmt.Printf("%v", string(r))
-		if i < len(rs)-1 {
-			fmt.Print(" ｜ ")
-		}
-	}
-	fmt.Print("\n")
-	fmt.Println("ーーーーーーーーーーーーーー")
-
-	for j := 1; j < wallThresholdNum; j++ {
-		fmt.Printf("%d", j)
-		fmt.Print(" ｜ ")
-		for i := 1; i < wallThresholdNum; i++ {
-			fmt.Print(CharacterToStr(g.Board.Cells[i][j]))
-			fmt.Print(" ｜ ")
-		}
-		fmt.Print("\n")
-	}
-
-	fmt.Println("ーーーーーーーーーーーーーー")
-
-	fmt.Printf("Score: BLACK=%d, WHITE=%d REST=%d\n",
-		g.Board.Score(Black), g.Board.Score(White),
-		g.Board.Rest(),
-	)
-
-	fmt.Print("\n")
-
-}
@@ -0,0 +1,92 @@
+package game
+
+import "errors"
+
+var (
+	// ErrOutOfHistory 記録されている範囲外の手数に移動しようとした
+	ErrOutOfHistory = errors.New("out of history")
+	// ErrNoTakeback 待ったで戻せる自分の手がない
+	ErrNoTakeback = errors.New("no move to take back")
+)
+
+// record 手を記録する。待ったで戻した後に打った場合は、やり直し用の手を捨てて新しい手で上書きする
+func (g *Game) record(p Ply) {
+	g.history = append(g.history[:g.cursor], p)
+	g.cursor++
+}
+
+// History 現在の盤面までに打たれた手とパスを順に返す
+func (g *Game) History() []Ply {
+	return append([]Ply(nil), g.history[:g.cursor]...)
+}
+
+// Len 記録されている手数。待ったで戻した手も含む
+func (g *Game) Len() int {
+	return len(g.history)
+}
+
+// Cursor 現在の盤面が何手目か。初期盤面は0
+func (g *Game) Cursor() int {
+	return g.cursor
+}
+
+// Undo 1手戻す
+func (g *Game) Undo() error {
+	return g.Jump(g.cursor - 1)
+}
+
+// Redo 戻した手を1手進める
+func (g *Game) Redo() error {
+	return g.Jump(g.cursor + 1)
+}
+
+// Jump n手目の盤面に移動する。差分を持たずに初期盤面から打ち直す。
+// 移動後に新しく手を打つと、n手目より後の記録は捨てられる
+func (g *Game) Jump(n int) error {
+	if n < 0 || len(g.history) < n {
+		return ErrOutOfHistory
+	}
+
+	b := g.initial.Clone()
+	turn := Black
+	for _, p := range g.history[:n] {
+		if !p.Pass {
+			if err := b.PutStone(p.X, p.Y, p.Character); err != nil {
+				return err
+			}
+		}
+		turn = OpponentCharacter(p.Character)
+	}
+
+	g.Board = b
+	g.turn = turn
+	g.cursor = n
+	// 投了などで終わっていても、戻した盤面の状態で終局を判定し直す
+	g.finished = false
+	g.termination = NotTerminated
+	g.loser = None
+	if g.IsGameOver() {
+		g.finish(Completed, None)
+	}
+	return nil
+}
+
+// TakebackPly 色cが待ったをした場合に戻る手数。cが最後に打った手の直前まで戻す
+func (g *Game) TakebackPly(c Character) (int, error) {
+	for i := g.cursor - 1; i >= 0; i-- {
+		if p := g.history[i]; p.Character == c && !p.Pass {
+			return i, nil
+		}
+	}
+	return 0, ErrNoTakeback
+}
+
+// LastMove 現在の盤面で最後に石が置かれた手。パスは含めない。まだ石が置かれていなければfalse
+func (g *Game) LastMove() (Ply, bool) {
+	for i := g.cursor - 1; i >= 0; i-- {
+		if p := g.history[i]; !p.Pass {
+			return p, true
+		}
+	}
+	return Ply{}, false
+}
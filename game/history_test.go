@@ -0,0 +1,202 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// playFirstMoves 初期盤面から、毎回最初に見つかる置ける場所にn手打つ
+func playFirstMoves(t *testing.T, g *Game, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		m := legalCells(g.Board, g.Turn())[0]
+		if _, err := g.Move(m[0], m[1], g.Turn()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestUndoRedoAtEnds(t *testing.T) {
+	g := NewGame(None)
+	if err := g.Undo(); !errors.Is(err, ErrOutOfHistory) {
+		t.Errorf("Undo on the initial board = %v, want ErrOutOfHistory", err)
+	}
+	if err := g.Redo(); !errors.Is(err, ErrOutOfHistory) {
+		t.Errorf("Redo without history = %v, want ErrOutOfHistory", err)
+	}
+
+	playFirstMoves(t, g, 3)
+	want := NewGame(None)
+	playFirstMoves(t, want, 3)
+	if err := g.Redo(); !errors.Is(err, ErrOutOfHistory) {
+		t.Errorf("Redo at the latest move = %v, want ErrOutOfHistory", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := g.Undo(); err != nil {
+			t.Fatalf("Undo %d: %v", i+1, err)
+		}
+	}
+	sameGame(t, g, NewGame(None))
+	if g.Turn() != Black || g.Cursor() != 0 || g.Len() != 3 {
+		t.Errorf("after undoing every move: turn %v, cursor %d, len %d, want %v, 0, 3", g.Turn(), g.Cursor(), g.Len(), Black)
+	}
+	if err := g.Undo(); !errors.Is(err, ErrOutOfHistory) {
+		t.Errorf("Undo past the initial board = %v, want ErrOutOfHistory", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := g.Redo(); err != nil {
+			t.Fatalf("Redo %d: %v", i+1, err)
+		}
+	}
+	sameGame(t, g, want)
+	if g.Turn() != want.Turn() {
+		t.Errorf("turn after redoing every move = %v, want %v", g.Turn(), want.Turn())
+	}
+	if err := g.Redo(); !errors.Is(err, ErrOutOfHistory) {
+		t.Errorf("Redo past the latest move = %v, want ErrOutOfHistory", err)
+	}
+}
+
+// TestMoveDiscardsRedo 戻した後に新しく手を打つと、やり直し用の手は捨てられる
+func TestMoveDiscardsRedo(t *testing.T) {
+	g := NewGame(None)
+	playFirstMoves(t, g, 4)
+	undone := g.History()[2]
+	if err := g.Jump(2); err != nil {
+		t.Fatal(err)
+	}
+
+	var m [2]int32
+	for _, c := range legalCells(g.Board, g.Turn()) {
+		if c[0] != undone.X || c[1] != undone.Y {
+			m = c
+		}
+	}
+	if _, err := g.Move(m[0], m[1], g.Turn()); err != nil {
+		t.Fatal(err)
+	}
+	if g.Len() != 3 || g.Cursor() != 3 {
+		t.Errorf("len %d, cursor %d after moving, want 3, 3", g.Len(), g.Cursor())
+	}
+	if p := g.History()[2]; p.X != m[0] || p.Y != m[1] {
+		t.Errorf("ply 3 = %v, want the new move %v", p, m)
+	}
+	if err := g.Redo(); !errors.Is(err, ErrOutOfHistory) {
+		t.Errorf("Redo after a new move = %v, want ErrOutOfHistory", err)
+	}
+}
+
+func TestJumpOutOfRange(t *testing.T) {
+	g := NewGame(None)
+	playFirstMoves(t, g, 3)
+	want := NewGame(None)
+	playFirstMoves(t, want, 3)
+	for _, n := range []int{-1, 4, 100} {
+		if err := g.Jump(n); !errors.Is(err, ErrOutOfHistory) {
+			t.Errorf("Jump(%d) = %v, want ErrOutOfHistory", n, err)
+		}
+		// 範囲外への移動では盤面を変えない
+		sameGame(t, g, want)
+	}
+	if err := g.Jump(3); err != nil {
+		t.Errorf("Jump to the latest move: %v", err)
+	}
+}
+
+// TestJumpRejudgesFinish 終局した対局を戻すと続きを打てるようになり、最後まで進めると再び終局する
+func TestJumpRejudgesFinish(t *testing.T) {
+	g := randomGames(1, 1)[0]
+	end := g.Len()
+	if err := g.Jump(end - 1); err != nil {
+		t.Fatal(err)
+	}
+	if g.Finished() {
+		t.Errorf("finished after going back from the last move")
+	}
+	if err := g.Jump(end); err != nil {
+		t.Fatal(err)
+	}
+	if !g.Finished() {
+		t.Errorf("not finished after returning to the last move")
+	}
+}
+
+func TestTakebackPly(t *testing.T) {
+	g := NewGame(None)
+	if _, err := g.TakebackPly(Black); !errors.Is(err, ErrNoTakeback) {
+		t.Errorf("TakebackPly(Black) on the initial board = %v, want ErrNoTakeback", err)
+	}
+	playFirstMoves(t, g, 1)
+	if _, err := g.TakebackPly(White); !errors.Is(err, ErrNoTakeback) {
+		t.Errorf("TakebackPly(White) before white moved = %v, want ErrNoTakeback", err)
+	}
+	playFirstMoves(t, g, 2)
+	// 白の手番では、黒の待ったは直前の自分の手だけを、白の待ったは黒の手と自分の手を戻す
+	for _, c := range []struct {
+		c    Character
+		want int
+	}{
+		{Black, 2},
+		{White, 1},
+	} {
+		if n, err := g.TakebackPly(c.c); err != nil || n != c.want {
+			t.Errorf("TakebackPly(%v) = %d, %v, want %d", c.c, n, err, c.want)
+		}
+	}
+}
+
+// TestTakebackAcrossPass パスは待ったで戻す手に数えず、その前に打った手まで戻す
+func TestTakebackAcrossPass(t *testing.T) {
+	var g *Game
+	pass := -1
+	for _, rg := range randomGames(1, 100) {
+		for i, p := range rg.History() {
+			// パスの直後に相手が打った場面を探す
+			if p.Pass && i+1 < rg.Len() && !rg.History()[i+1].Pass {
+				g, pass = rg, i
+				break
+			}
+		}
+		if g != nil {
+			break
+		}
+	}
+	if g == nil {
+		t.Fatal("no game with a pass")
+	}
+	passed := g.History()[pass].Character
+	if err := g.Jump(pass + 2); err != nil {
+		t.Fatal(err)
+	}
+
+	// パスした側の待ったは、パスより前に自分が打った手まで戻す
+	n, err := g.TakebackPly(passed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n >= pass {
+		t.Errorf("TakebackPly(%v) = %d, want before the pass at %d", passed, n, pass)
+	}
+	for i, p := range g.History()[n:] {
+		if p.Character == passed && !p.Pass && i > 0 {
+			t.Errorf("TakebackPly(%v) = %d skipped its move at %d", passed, n, n+i)
+		}
+	}
+	if p := g.History()[n]; p.Character != passed || p.Pass {
+		t.Errorf("ply %d = %v, want a move by %v", n, p, passed)
+	}
+
+	// 相手の待ったは、パスの後に打った手だけを戻す
+	if n, err := g.TakebackPly(OpponentCharacter(passed)); err != nil || n != pass+1 {
+		t.Errorf("TakebackPly(%v) = %d, %v, want %d", OpponentCharacter(passed), n, err, pass+1)
+	}
+
+	if err := g.Jump(n); err != nil {
+		t.Fatal(err)
+	}
+	if g.Turn() != passed || g.Cursor() != n {
+		t.Errorf("after the takeback: turn %v, cursor %d, want %v, %d", g.Turn(), g.Cursor(), passed, n)
+	}
+}
@@ -1,6 +1,8 @@
 package game
 
 type Player struct {
-	ID        int32
+	ID        string // Registryが発行するID
 	Character Character
+	Bot       bool   // サーバー側のAI
+	Name      string // プロフィールの名前
 }
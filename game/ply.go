@@ -0,0 +1,9 @@
+package game
+
+// Ply 1手分の記録。パスの場合はPassがtrueで、座標は使わない
+type Ply struct {
+	Character Character
+	X         int32
+	Y         int32
+	Pass      bool
+}
@@ -0,0 +1,137 @@
+// Package rating Glicko-2によるレーティング。
+// 1局ごとに1評価期間として更新する
+// 参考: Mark E. Glickman, "Example of the Glicko-2 system"
+package rating
+
+import "math"
+
+const (
+	// DefaultRating 初期レーティング
+	DefaultRating = 1500.0
+	// DefaultDeviation 初期のレーティング偏差。大きいほど実力が不確か
+	DefaultDeviation = 350.0
+	// DefaultVolatility 初期のレーティング変動率
+	DefaultVolatility = 0.06
+
+	// tau 変動率の変化のしやすさ。0.3〜1.2の範囲で、小さいほど変動率が変わりにくい
+	tau = 0.5
+	// scale Glicko形式とGlicko-2形式の換算係数
+	scale = 173.7178
+	// epsilon 変動率を求める際の収束判定
+	epsilon = 0.000001
+)
+
+// Rating プレイヤーのレーティング。値はGlicko形式(初期値1500)で持つ
+type Rating struct {
+	Rating     float64
+	Deviation  float64
+	Volatility float64
+}
+
+// Default 対局したことがないプレイヤーのレーティング
+func Default() Rating {
+	return Rating{
+		Rating:     DefaultRating,
+		Deviation:  DefaultDeviation,
+		Volatility: DefaultVolatility,
+	}
+}
+
+// 対局結果のスコア
+const (
+	Win  = 1.0
+	Draw = 0.5
+	Loss = 0.0
+)
+
+// Result 評価期間中の1局の結果
+type Result struct {
+	Opponent Rating  // 対局前の相手のレーティング
+	Score    float64 // Win, Draw, Loss
+}
+
+// Update 評価期間中の結果からレーティングを更新する。対局していなければ偏差だけが大きくなる
+func Update(r Rating, results []Result) Rating {
+	mu := (r.Rating - DefaultRating) / scale
+	phi := r.Deviation / scale
+
+	if len(results) == 0 {
+		return Rating{
+			Rating:     r.Rating,
+			Deviation:  math.Sqrt(phi*phi+r.Volatility*r.Volatility) * scale,
+			Volatility: r.Volatility,
+		}
+	}
+
+	// 推定分散vと、レーティングの改善量delta
+	var invV, sum float64
+	for _, res := range results {
+		muJ := (res.Opponent.Rating - DefaultRating) / scale
+		phiJ := res.Opponent.Deviation / scale
+		g := gPhi(phiJ)
+		e := expected(mu, muJ, g)
+		invV += g * g * e * (1 - e)
+		sum += g * (res.Score - e)
+	}
+	v := 1 / invV
+	delta := v * sum
+
+	sigma := volatility(phi, r.Volatility, v, delta)
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	newMu := mu + newPhi*newPhi*sum
+
+	return Rating{
+		Rating:     newMu*scale + DefaultRating,
+		Deviation:  newPhi * scale,
+		Volatility: sigma,
+	}
+}
+
+// Expected rがoppに勝つ期待値
+func Expected(r Rating, opp Rating) float64 {
+	return expected((r.Rating-DefaultRating)/scale, (opp.Rating-DefaultRating)/scale, gPhi(opp.Deviation/scale))
+}
+
+func gPhi(phi float64) float64 {
+	return 1 / math.Sqrt(1+3*phi*phi/(math.Pi*math.Pi))
+}
+
+func expected(mu float64, muJ float64, g float64) float64 {
+	return 1 / (1 + math.Exp(-g*(mu-muJ)))
+}
+
+// volatility 新しい変動率をIllinois法で求める
+func volatility(phi float64, sigma float64, v float64, delta float64) float64 {
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-d)/(2*d*d) - (x-a)/(tau*tau)
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		B = a - k*tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > epsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+	return math.Exp(A / 2)
+}
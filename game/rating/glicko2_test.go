@@ -0,0 +1,45 @@
+package rating
+
+import (
+	"math"
+	"testing"
+)
+
+// TestUpdateGlickmanExample Glickmanの"Example of the Glicko-2 system"の計算例を再現する
+func TestUpdateGlickmanExample(t *testing.T) {
+	player := Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	results := []Result{
+		{Opponent: Rating{Rating: 1400, Deviation: 30, Volatility: DefaultVolatility}, Score: Win},
+		{Opponent: Rating{Rating: 1550, Deviation: 100, Volatility: DefaultVolatility}, Score: Loss},
+		{Opponent: Rating{Rating: 1700, Deviation: 300, Volatility: DefaultVolatility}, Score: Loss},
+	}
+
+	got := Update(player, results)
+	for _, c := range []struct {
+		name      string
+		got, want float64
+		tolerance float64
+	}{
+		{"rating", got.Rating, 1464.06, 0.01},
+		{"deviation", got.Deviation, 151.52, 0.01},
+		{"volatility", got.Volatility, 0.05999, 0.00001},
+	} {
+		if math.Abs(c.got-c.want) > c.tolerance {
+			t.Errorf("%v = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+// TestUpdateWithoutGames 対局しなければレーティングは変わらず、偏差だけが大きくなる
+func TestUpdateWithoutGames(t *testing.T) {
+	player := Rating{Rating: 1500, Deviation: 200, Volatility: 0.06}
+	got := Update(player, nil)
+	if got.Rating != player.Rating || got.Volatility != player.Volatility {
+		t.Errorf("Update without games = %+v, want rating and volatility unchanged", got)
+	}
+	// φ* = sqrt(φ² + σ²)をGlicko形式に戻した値
+	want := scale * math.Sqrt(math.Pow(200/scale, 2)+0.06*0.06)
+	if math.Abs(got.Deviation-want) > 1e-9 {
+		t.Errorf("deviation = %v, want %v", got.Deviation, want)
+	}
+}
@@ -0,0 +1,204 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRecord 棋譜として解釈できない
+var ErrInvalidRecord = errors.New("invalid record")
+
+// 棋譜の座標表記。列をa-h、行を1-8で表す。例: f5 = (6, 5)
+const (
+	columnLetters = "abcdefgh"
+	passNotation  = "pa"
+)
+
+// PlyNotation 1手を"f5"の形式で表す。パスは"pa"
+func PlyNotation(p Ply) string {
+	if p.Pass {
+		return passNotation
+	}
+	return fmt.Sprintf("%c%d", columnLetters[p.X-1], p.Y)
+}
+
+// parseNotation "f5"の形式(大文字も可)を座標に変換する
+func parseNotation(s string) (int32, int32, error) {
+	s = strings.ToLower(s)
+	if len(s) != 2 {
+		return 0, 0, fmt.Errorf("%w: move=%q", ErrInvalidRecord, s)
+	}
+	x := int32(strings.IndexByte(columnLetters, s[0])) + 1
+	y := int32(s[1] - '0')
+	if x < 1 || BoardSize < x || y < 1 || BoardSize < y {
+		return 0, 0, fmt.Errorf("%w: move=%q", ErrInvalidRecord, s)
+	}
+	return x, y, nil
+}
+
+// Transcript 棋譜を"f5d6c3..."の形式で返す。パスは表記しない
+func (g *Game) Transcript() string {
+	var sb strings.Builder
+	for _, p := range g.History() {
+		if p.Pass {
+			continue
+		}
+		sb.WriteString(PlyNotation(p))
+	}
+	return sb.String()
+}
+
+// ParseTranscript "f5d6c3..."の形式の棋譜を初期盤面から再生する。
+// パスは表記されないので、置ける場所がなければ自動でパスする。"pa"と明示されていても良い
+func ParseTranscript(s string) (*Game, error) {
+	s = strings.Join(strings.Fields(s), "")
+	if len(s)%2 != 0 {
+		return nil, fmt.Errorf("%w: odd length transcript", ErrInvalidRecord)
+	}
+
+	g := NewGame(None)
+	for i := 0; i < len(s); i += 2 {
+		if err := g.replay(s[i:i+2], None); err != nil {
+			return nil, fmt.Errorf("ply %d: %w", i/2+1, err)
+		}
+	}
+	return g, nil
+}
+
+// replay 棋譜の1手を色cの手として再生する。cがNoneならその時点の手番の手とする。
+// 手番の色が置けないのにcの手が記録されている場合は、パスが省略されているとみなして先にパスする
+func (g *Game) replay(notation string, c Character) error {
+	if g.finished {
+		return fmt.Errorf("%w: move after the game finished", ErrInvalidRecord)
+	}
+	if strings.ToLower(notation) == passNotation {
+		if c == None {
+			c = g.Turn()
+		}
+		return g.Pass(c)
+	}
+	if g.MustPass() && g.Turn() != c {
+		if err := g.Pass(g.Turn()); err != nil {
+			return err
+		}
+	}
+	if c == None {
+		c = g.Turn()
+	}
+	x, y, err := parseNotation(notation)
+	if err != nil {
+		return err
+	}
+	// 盤面に置けるかどうかはPutStoneで検証される
+	_, err = g.Move(x, y, c)
+	return err
+}
+
+// GGFHeader GGF(Generic Game Format)のヘッダ情報
+type GGFHeader struct {
+	Place string    // PC 対局場所
+	Date  time.Time // DT 対局日時
+	Black string    // PB 黒のプレイヤー名
+	White string    // PW 白のプレイヤー名
+}
+
+const (
+	ggfDateLayout = "2006.01.02_15:04:05.MST"
+	// 初期盤面。上の行から順に、黒を*、白をO、空きを-で表し、最後に手番を付ける
+	ggfInitialBoard = "8 ---------------------------O*------*O--------------------------- *"
+)
+
+// GGF 棋譜をGGFで返す。終了していれば結果(黒から見た石差)も含める
+func (g *Game) GGF(h GGFHeader) string {
+	var sb strings.Builder
+	sb.WriteString("(;GM[Othello]")
+	fmt.Fprintf(&sb, "PC[%s]", h.Place)
+	if !h.Date.IsZero() {
+		fmt.Fprintf(&sb, "DT[%s]", h.Date.Format(ggfDateLayout))
+	}
+	fmt.Fprintf(&sb, "PB[%s]PW[%s]", h.Black, h.White)
+	if g.finished {
+		fmt.Fprintf(&sb, "RE[%+d]", g.Board.Score(Black)-g.Board.Score(White))
+	}
+	fmt.Fprintf(&sb, "TY[8]BO[%s]", ggfInitialBoard)
+	for _, p := range g.History() {
+		fmt.Fprintf(&sb, "%s[%s]", ggfColor(p.Character), strings.ToUpper(PlyNotation(p)))
+	}
+	sb.WriteString(";)")
+	return sb.String()
+}
+
+func ggfColor(c Character) string {
+	if c == Black {
+		return "B"
+	}
+	return "W"
+}
+
+// ParseGGF GGFの棋譜を初期盤面から再生する。8×8の初期盤面から始まる対局のみ扱える
+func ParseGGF(s string) (*Game, *GGFHeader, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "(;") || !strings.HasSuffix(s, ";)") {
+		return nil, nil, fmt.Errorf("%w: not a GGF game", ErrInvalidRecord)
+	}
+	body := s[2 : len(s)-2]
+
+	g := NewGame(None)
+	h := &GGFHeader{}
+	// KEY[value]の繰り返し
+	for len(strings.TrimSpace(body)) > 0 {
+		body = strings.TrimSpace(body)
+		open := strings.IndexByte(body, '[')
+		end := strings.IndexByte(body, ']')
+		if open < 0 || end < open {
+			return nil, nil, fmt.Errorf("%w: broken property %q", ErrInvalidRecord, body)
+		}
+		key, value := body[:open], body[open+1:end]
+		body = body[end+1:]
+
+		switch key {
+		case "GM":
+			if !strings.EqualFold(value, "Othello") {
+				return nil, nil, fmt.Errorf("%w: unsupported game %q", ErrInvalidRecord, value)
+			}
+		case "PC":
+			h.Place = value
+		case "DT":
+			// 日時の形式は実装によって異なるので、読めなければ無視する
+			if t, err := time.Parse(ggfDateLayout, value); err == nil {
+				h.Date = t
+			}
+		case "PB":
+			h.Black = value
+		case "PW":
+			h.White = value
+		case "TY":
+			if value != "8" {
+				return nil, nil, fmt.Errorf("%w: unsupported board type %q", ErrInvalidRecord, value)
+			}
+		case "BO":
+			// 行の間に空白を入れるかは実装によって異なるので、空白を除いた升目と手番で比べる
+			if withoutSpaces(value) != withoutSpaces(ggfInitialBoard) {
+				return nil, nil, fmt.Errorf("%w: only the initial position is supported", ErrInvalidRecord)
+			}
+		case "B", "W":
+			c := Black
+			if key == "W" {
+				c = White
+			}
+			// 手には"f5/評価値/消費時間"のように付加情報がつくことがある
+			notation, _, _ := strings.Cut(value, "/")
+			if err := g.replay(notation, c); err != nil {
+				return nil, nil, fmt.Errorf("ply %d: %w", g.cursor+1, err)
+			}
+		}
+	}
+	return g, h, nil
+}
+
+// withoutSpaces 空白を全て除いた文字列
+func withoutSpaces(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
@@ -0,0 +1,175 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// randomGames 種から決まるランダムな対局を終局までn局打つ
+func randomGames(seed int64, n int) []*Game {
+	rnd := rand.New(rand.NewSource(seed))
+	games := make([]*Game, n)
+	for i := range games {
+		g := NewGame(None)
+		for !g.Finished() {
+			if g.MustPass() {
+				if err := g.Pass(g.Turn()); err != nil {
+					panic(err)
+				}
+				continue
+			}
+			moves := legalCells(g.Board, g.Turn())
+			m := moves[rnd.Intn(len(moves))]
+			if _, err := g.Move(m[0], m[1], g.Turn()); err != nil {
+				panic(err)
+			}
+		}
+		games[i] = g
+	}
+	return games
+}
+
+func hasPass(g *Game) bool {
+	for _, p := range g.History() {
+		if p.Pass {
+			return true
+		}
+	}
+	return false
+}
+
+// sameGame 手順(パスを含む)、盤面、終局したかが同じか
+func sameGame(t *testing.T, got, want *Game) {
+	t.Helper()
+	if !reflect.DeepEqual(got.History(), want.History()) {
+		t.Errorf("history = %v, want %v", got.History(), want.History())
+	}
+	for x := int32(1); x <= BoardSize; x++ {
+		for y := int32(1); y <= BoardSize; y++ {
+			if got.Board.Cell(x, y) != want.Board.Cell(x, y) {
+				t.Fatalf("cell (%d, %d) = %v, want %v", x, y, got.Board.Cell(x, y), want.Board.Cell(x, y))
+			}
+		}
+	}
+	if got.Finished() != want.Finished() {
+		t.Errorf("finished = %v, want %v", got.Finished(), want.Finished())
+	}
+}
+
+func TestTranscriptRoundTrip(t *testing.T) {
+	passes := 0
+	for _, g := range randomGames(1, 100) {
+		if hasPass(g) {
+			passes++
+		}
+		parsed, err := ParseTranscript(g.Transcript())
+		if err != nil {
+			t.Fatalf("ParseTranscript(%q): %v", g.Transcript(), err)
+		}
+		sameGame(t, parsed, g)
+	}
+	// パスは表記しないので、パスを含む対局で省略したパスを補えるかを確かめる
+	if passes == 0 {
+		t.Fatal("no game with a pass was played")
+	}
+}
+
+func TestGGFRoundTrip(t *testing.T) {
+	header := GGFHeader{
+		Place: "reversi",
+		Date:  time.Date(2024, 4, 1, 12, 34, 56, 0, time.UTC),
+		Black: "alice",
+		White: "bob",
+	}
+	passes := 0
+	for _, g := range randomGames(2, 100) {
+		if hasPass(g) {
+			passes++
+		}
+		parsed, h, err := ParseGGF(g.GGF(header))
+		if err != nil {
+			t.Fatalf("ParseGGF(%q): %v", g.GGF(header), err)
+		}
+		sameGame(t, parsed, g)
+		if h.Place != header.Place || !h.Date.Equal(header.Date) || h.Black != header.Black || h.White != header.White {
+			t.Errorf("header = %+v, want %+v", *h, header)
+		}
+	}
+	if passes == 0 {
+		t.Fatal("no game with a pass was played")
+	}
+}
+
+func TestParseTranscriptRejectsMalformedInput(t *testing.T) {
+	for _, s := range []string{
+		"f5d",  // 長さが奇数
+		"f5z9", // 盤面の外
+		"f5f0", // 行が0
+		"f5f5", // 石のあるマス
+		"a1",   // 置けないマス
+		"f5pa", // 置ける場所があるのにパス
+		"f5-6", // 座標ではない
+	} {
+		if _, err := ParseTranscript(s); err == nil {
+			t.Errorf("ParseTranscript(%q) succeeded", s)
+		}
+	}
+
+	if _, err := ParseTranscript("f5z9"); !errors.Is(err, ErrInvalidRecord) {
+		t.Errorf("ParseTranscript(%q) = %v, want ErrInvalidRecord", "f5z9", err)
+	}
+	if _, err := ParseTranscript("a1"); !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("ParseTranscript(%q) = %v, want ErrIllegalMove", "a1", err)
+	}
+}
+
+func TestParseTranscriptRejectsMovesAfterTheEnd(t *testing.T) {
+	g := randomGames(3, 1)[0]
+	moves := legalCells(NewBoard(), Black)
+	s := g.Transcript() + PlyNotation(Ply{X: moves[0][0], Y: moves[0][1]})
+	if _, err := ParseTranscript(s); !errors.Is(err, ErrInvalidRecord) {
+		t.Errorf("ParseTranscript with a move after the end = %v, want ErrInvalidRecord", err)
+	}
+}
+
+// TestParseGGFFromGGS GGSの形式で書かれた棋譜を読み込む。盤面は行ごとに空白で区切られ、手には評価値と消費時間がつく
+func TestParseGGFFromGGS(t *testing.T) {
+	s := "(;GM[Othello]PC[GGS/os]DT[2003.11.12_17:24:48.MST]PB[Saio1200]PW[Zebra]RB[2500.00]RW[2600.00]" +
+		"TI[05:00//02:00]TY[8]RE[?]" +
+		"BO[8 -------- -------- -------- ---O*--- ---*O--- -------- -------- -------- *]" +
+		"B[F5//0.01]W[D6/-2.00/3.20]B[C3]W[D3/0.00/1.5]B[C4];)"
+	g, h, err := ParseGGF(s)
+	if err != nil {
+		t.Fatalf("ParseGGF: %v", err)
+	}
+	if got, want := g.Transcript(), "f5d6c3d3c4"; got != want {
+		t.Errorf("transcript = %q, want %q", got, want)
+	}
+	if h.Place != "GGS/os" || h.Black != "Saio1200" || h.White != "Zebra" {
+		t.Errorf("header = %+v", *h)
+	}
+}
+
+func TestParseGGFRejectsMalformedInput(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"GM[Othello]TY[8]",         // (; ;)がない
+		"(;GM[Othello]TY[8]B[F5;)", // 閉じていない
+		"(;GM[Chess]TY[8];)",       // オセロではない
+		"(;GM[Othello]TY[10];)",    // 8×8ではない
+		"(;GM[Othello]TY[8]BO[8 " + emptyGGFBoard + " *];)", // 初期盤面ではない
+		"(;GM[Othello]TY[8]B[F5]W[Z9];)",                    // 盤面の外
+		"(;GM[Othello]TY[8]B[A1];)",                         // 置けないマス
+		"(;GM[Othello]TY[8]B[F5]B[F5];)",                    // 石のあるマス
+	} {
+		if _, _, err := ParseGGF(s); err == nil {
+			t.Errorf("ParseGGF(%q) succeeded", s)
+		}
+	}
+}
+
+// emptyGGFBoard 石のない盤面
+const emptyGGFBoard = "----------------------------------------------------------------"
@@ -0,0 +1,244 @@
+package game
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Renderer 盤面を文字で書き出す。書き出し先を選べるので、標準出力以外にも画面やログに描ける
+type Renderer interface {
+	Render(w io.Writer, g *Game) error
+}
+
+// RenderOptions 盤面に添える印。どの描き方でも共通
+type RenderOptions struct {
+	Hints    bool // 手番の色が石を置ける場所に印をつける
+	LastMove bool // 最後に石が置かれた場所を強調する
+}
+
+// cell 盤面に描くセルの状態
+type cell struct {
+	Character Character
+	Hint      bool // 手番の色が置ける
+	Last      bool // 最後に石が置かれた
+}
+
+// cells 印を含めたセルの状態を、行(y)ごとに返す
+func (o RenderOptions) cells(g *Game) [BoardSize][BoardSize]cell {
+	var cs [BoardSize][BoardSize]cell
+	last, ok := g.LastMove()
+	hints := o.Hints && !g.finished
+	for y := int32(1); y <= BoardSize; y++ {
+		for x := int32(1); x <= BoardSize; x++ {
+			c := cell{Character: g.Board.Cell(x, y)}
+			c.Hint = hints && c.Character == Empty && g.Board.CanPutStone(x, y, g.turn)
+			c.Last = o.LastMove && ok && last.X == x && last.Y == y
+			cs[y-1][x-1] = c
+		}
+	}
+	return cs
+}
+
+// header 対局者の色。観戦者の場合は書かない
+func header(sb *strings.Builder, g *Game, name func(Character) string) {
+	sb.WriteString("\n")
+	if g.me != None {
+		fmt.Fprintf(sb, "You: %v\n", name(g.me))
+	}
+}
+
+// footer 石の数
+func footer(sb *strings.Builder, g *Game) {
+	fmt.Fprintf(sb, "Score: BLACK=%d, WHITE=%d REST=%d\n\n",
+		g.Board.Score(Black), g.Board.Score(White),
+		g.Board.Rest(),
+	)
+}
+
+// ASCIIRenderer ASCIIだけで描く。黒はX、白はO、置ける場所は*。最後の手は()で囲む
+type ASCIIRenderer struct {
+	RenderOptions
+}
+
+func (r ASCIIRenderer) Render(w io.Writer, g *Game) error {
+	var sb strings.Builder
+	header(&sb, g, asciiStone)
+	sb.WriteString(" ")
+	for _, c := range columnLetters {
+		fmt.Fprintf(&sb, " %c", c-'a'+'A')
+	}
+	sb.WriteString("\n")
+	for y, row := range r.cells(g) {
+		fmt.Fprintf(&sb, "%d", y+1)
+		// 最後の手は、前後の区切りの空白を括弧に置き換えて幅を変えずに囲む
+		closing := false
+		for _, c := range row {
+			switch {
+			case c.Last:
+				sb.WriteString("(")
+			case closing:
+				sb.WriteString(")")
+			default:
+				sb.WriteString(" ")
+			}
+			closing = c.Last
+			if c.Hint {
+				sb.WriteString("*")
+			} else {
+				sb.WriteString(asciiStone(c.Character))
+			}
+		}
+		if closing {
+			sb.WriteString(")")
+		}
+		sb.WriteString("\n")
+	}
+	footer(&sb, g)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func asciiStone(c Character) string {
+	switch c {
+	case Black:
+		return "X"
+	case White:
+		return "O"
+	}
+	return "."
+}
+
+// UnicodeRenderer 全角の罫線と記号で描く。置ける場所は・、最後の手は[]で囲む
+type UnicodeRenderer struct {
+	RenderOptions
+}
+
+func (r UnicodeRenderer) Render(w io.Writer, g *Game) error {
+	var sb strings.Builder
+	header(&sb, g, CharacterToStr)
+	sb.WriteString(" ｜")
+	for i, c := range columnLetters {
+		fmt.Fprintf(&sb, " %c ", c-'a'+'A')
+		if i < len(columnLetters)-1 {
+			sb.WriteString("｜")
+		}
+	}
+	sb.WriteString("\n")
+	sb.WriteString("ーーーーーーーーーーーーーー\n")
+	for y, row := range r.cells(g) {
+		fmt.Fprintf(&sb, "%d ｜", y+1)
+		for _, c := range row {
+			s := CharacterToStr(c.Character)
+			if c.Hint {
+				s = "・"
+			}
+			if c.Last {
+				fmt.Fprintf(&sb, "[%v]｜", s)
+			} else {
+				fmt.Fprintf(&sb, " %v ｜", s)
+			}
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("ーーーーーーーーーーーーーー\n")
+	footer(&sb, g)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// ANSIのエスケープシーケンス
+const (
+	ansiReset      = "\x1b[0m"
+	ansiBoard      = "\x1b[42m" // 盤面は緑の背景
+	ansiLast       = "\x1b[43m" // 最後の手は黄色の背景
+	ansiBlack      = "\x1b[30m" // 黒石
+	ansiWhite      = "\x1b[97m" // 白石
+	ansiHint       = "\x1b[33m" // 置ける場所
+	ansiCoordinate = "\x1b[2m"  // 座標は薄く
+	ansiBold       = "\x1b[1m"  // 石は太く
+	ansiStone      = "●"
+	ansiHintMark   = "·"
+)
+
+// ANSIRenderer 端末の色で描く。緑の盤面に黒石と白石を置き、最後の手は背景を黄色にする。色に対応した端末で使う
+type ANSIRenderer struct {
+	RenderOptions
+}
+
+func (r ANSIRenderer) Render(w io.Writer, g *Game) error {
+	var sb strings.Builder
+	header(&sb, g, func(c Character) string {
+		return ansiBoard + ansiStoneString(c) + ansiReset
+	})
+	sb.WriteString(ansiCoordinate + " ")
+	for _, c := range columnLetters {
+		fmt.Fprintf(&sb, " %c", c-'a'+'A')
+	}
+	sb.WriteString(ansiReset + "\n")
+	for y, row := range r.cells(g) {
+		fmt.Fprintf(&sb, "%v%d%v", ansiCoordinate, y+1, ansiReset)
+		for _, c := range row {
+			bg := ansiBoard
+			if c.Last {
+				bg = ansiLast
+			}
+			sb.WriteString(bg + " ")
+			switch {
+			case c.Hint:
+				sb.WriteString(ansiHint + ansiHintMark)
+			case c.Character == Empty:
+				sb.WriteString(" ")
+			default:
+				sb.WriteString(ansiStoneString(c.Character))
+			}
+			sb.WriteString(ansiReset)
+		}
+		sb.WriteString(ansiBoard + " " + ansiReset + "\n")
+	}
+	footer(&sb, g)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// ansiStoneString 色をつけた石。背景は呼び出し元で決める
+func ansiStoneString(c Character) string {
+	if c == White {
+		return ansiBold + ansiWhite + ansiStone
+	}
+	return ansiBold + ansiBlack + ansiStone
+}
+
+// CompactRenderer 盤面を1行で描く。ログや一覧に向く。
+// 行を上から/で区切り、黒はX、白はO、空きは.、置ける場所は*、最後の手は小文字。続けて手番と石の数を書く
+//
+//	......../......../......../...OX.../...xXX../......../......../........ O 4-1
+type CompactRenderer struct {
+	RenderOptions
+}
+
+func (r CompactRenderer) Render(w io.Writer, g *Game) error {
+	var sb strings.Builder
+	for y, row := range r.cells(g) {
+		if y > 0 {
+			sb.WriteString("/")
+		}
+		for _, c := range row {
+			s := asciiStone(c.Character)
+			if c.Hint {
+				s = "*"
+			}
+			if c.Last {
+				s = strings.ToLower(s)
+			}
+			sb.WriteString(s)
+		}
+	}
+	turn := asciiStone(g.turn)
+	if g.finished {
+		turn = "-"
+	}
+	fmt.Fprintf(&sb, " %v %d-%d\n", turn, g.Board.Score(Black), g.Board.Score(White))
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -8,6 +8,19 @@ type Drawable interface {
 	GetRatio() int
 }
 
+// Random 抽選に使う乱数。種を指定した*rand.Randを渡せば、同じ抽選を再現できる
+type Random interface {
+	// Intn [0, n)の乱数
+	Intn(n int) int
+}
+
+// globalRandom math/randの共有の乱数。種を指定しない抽選に使う
+type globalRandom struct{}
+
+func (globalRandom) Intn(n int) int {
+	return rand.Intn(n)
+}
+
 type Reward struct {
 	CardID string
 	Ratio  int
@@ -25,14 +38,14 @@ var Rewards = []*Reward{
 }
 
 // ジェネリクスを使わない書き方
-func draw(drawables []Drawable) Drawable {
+func draw(drawables []Drawable, rnd Random) Drawable {
 	// 2. 提供割合の合計値を計算
 	var total int
 	for _, d := range drawables {
 		total += d.GetRatio()
 	}
 	// 3. 合計値の範囲で乱数を生成
-	random := rand.Intn(total)
+	random := rnd.Intn(total)
 
 	// 4. 乱数を元に抽選結果を決定
 	var temp int
@@ -55,12 +68,12 @@ func Draw() string {
 		drawables = append(drawables, e)
 	}
 	// draw関数の戻り値(Drawable)を*Rewardにキャストして値を取得
-	cardId := draw(drawables).(*Reward).CardID
+	cardId := draw(drawables, globalRandom{}).(*Reward).CardID
 	return "抽選されたカード: " + cardId
 }
 
 // drawGenerics Drawableインターフェース型の型パラメータTを定義し、引数、戻り値もTに変更して汎用化
-func drawGenerics[T Drawable](drawables []T) T {
+func drawGenerics[T Drawable](drawables []T, rnd Random) T {
 	// 2. 提供割合の合計値を計算
 	var total int
 	for _, d := range drawables {
@@ -68,7 +81,7 @@ func drawGenerics[T Drawable](drawables []T) T {
 	}
 
 	// 3. 合計値の範囲で乱数を生成
-	random := rand.Intn(total)
+	random := rnd.Intn(total)
 
 	// 4. 乱数を元に抽選結果を決定
 	var temp int
@@ -87,5 +100,11 @@ func drawGenerics[T Drawable](drawables []T) T {
 
 func DrawGenerics() string {
 	// 呼び出す側でRewards直接渡せる。Drawableへのキャストが暗黙的に行われる
-	return "抽選されたカード: " + drawGenerics(Rewards).CardID
+	return "抽選されたカード: " + drawGenerics(Rewards, globalRandom{}).CardID
+}
+
+// DrawFrom 提供割合に従ってdrawablesから1つ抽選する。サーバーで報酬の抽選表から引く場合と、
+// クライアントで公開された種から抽選をやり直して確かめる場合に使う
+func DrawFrom[T Drawable](drawables []T, rnd Random) T {
+	return drawGenerics(drawables, rnd)
 }
@@ -1,7 +1,45 @@
 package game
 
+import "errors"
+
+// ErrUnknownVariant 対応していないルール
+var ErrUnknownVariant = errors.New("unknown variant")
+
 type Room struct {
-	ID    int32
-	Host  *Player
-	Guest *Player
+	ID              string // Registryが発行するID
+	Host            *Player
+	Guest           *Player
+	TimeControl     TimeControl // 持ち時間。マッチングで作成した部屋では二人の希望から決める
+	Variant         Variant
+	Visibility      Visibility
+	AllowSpectators bool   // 観戦を許可する
+	InviteCode      string // CreateRoomで作成した部屋に参加するためのコード。マッチングで作成した部屋では空
+}
+
+// Visibility 部屋の公開範囲
+type Visibility int
+
+const (
+	// Public 部屋の一覧に表示され、誰でも参加できる
+	Public Visibility = iota
+	// Private 招待コードを知っているプレイヤーだけが参加できる
+	Private
+)
+
+// Variant ルールの種類。マッチングでは同じルールを希望するプレイヤー同士を組み合わせる
+type Variant int
+
+const (
+	// UnknownVariant 対応していないルール
+	UnknownVariant Variant = iota - 1
+	// Standard 通常のリバーシ
+	Standard
+)
+
+// Validate 対応しているルールかを確認する
+func (v Variant) Validate() error {
+	if v != Standard {
+		return ErrUnknownVariant
+	}
+	return nil
 }
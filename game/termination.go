@@ -0,0 +1,99 @@
+package game
+
+import "errors"
+
+var (
+	// ErrGameFinished 終了した対局に対して操作しようとした
+	ErrGameFinished = errors.New("game has finished")
+	// ErrCannotAbort 自分の初手を打った後は中止できない
+	ErrCannotAbort = errors.New("can not abort after your first move")
+)
+
+// Termination 対局の終わり方
+type Termination int
+
+const (
+	// NotTerminated 対局中
+	NotTerminated Termination = iota
+	// Completed 双方置ける場所がなくなって終局した
+	Completed
+	// Resigned 投了した
+	Resigned
+	// TimeForfeit 持ち時間を使い切った
+	TimeForfeit
+	// DrawAgreed 合意で引き分けにした
+	DrawAgreed
+	// Aborted 初手を打つ前に中止した
+	Aborted
+	// Abandoned 接続が切れたまま、再接続を待つ時間内に戻らなかった
+	Abandoned
+)
+
+// Termination 対局の終わり方。対局中はNotTerminated
+func (g *Game) Termination() Termination {
+	return g.termination
+}
+
+// Loser 投了や時間切れ、切断で負けた色。それ以外の終わり方や対局中はNone
+func (g *Game) Loser() Character {
+	return g.loser
+}
+
+// Resign cの投了で対局を終了する
+func (g *Game) Resign(c Character) error {
+	if g.finished {
+		return ErrGameFinished
+	}
+	if c != Black && c != White {
+		return ErrInvalidCharacter
+	}
+	g.finish(Resigned, c)
+	return nil
+}
+
+// TimeUp 持ち時間を使い切ったcの負けとして対局を終了する
+func (g *Game) TimeUp(c Character) {
+	g.finish(TimeForfeit, c)
+}
+
+// Abandon 接続が切れたまま戻らなかったcの負けとして対局を終了する
+func (g *Game) Abandon(c Character) error {
+	if g.finished {
+		return ErrGameFinished
+	}
+	if c != Black && c != White {
+		return ErrInvalidCharacter
+	}
+	g.finish(Abandoned, c)
+	return nil
+}
+
+// AgreeDraw 双方の合意で引き分けとして対局を終了する
+func (g *Game) AgreeDraw() error {
+	if g.finished {
+		return ErrGameFinished
+	}
+	g.finish(DrawAgreed, None)
+	return nil
+}
+
+// Abort 対局を中止する。勝敗はつけない。
+// cがまだ一手も打っていない間だけ中止できるので、先手は初手の前、後手は自分の初手の前まで中止できる
+func (g *Game) Abort(c Character) error {
+	if g.finished {
+		return ErrGameFinished
+	}
+	for _, p := range g.history[:g.cursor] {
+		if p.Character == c && !p.Pass {
+			return ErrCannotAbort
+		}
+	}
+	g.finish(Aborted, None)
+	return nil
+}
+
+func (g *Game) finish(t Termination, loser Character) {
+	g.finished = true
+	g.termination = t
+	g.loser = loser
+}
@@ -20,16 +20,151 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type PlayResponse_FinishedEvent_Termination int32
+
+const (
+	PlayResponse_FinishedEvent_UNKNOWN      PlayResponse_FinishedEvent_Termination = 0
+	PlayResponse_FinishedEvent_COMPLETED    PlayResponse_FinishedEvent_Termination = 1 // 双方置ける場所がなくなった
+	PlayResponse_FinishedEvent_RESIGNED     PlayResponse_FinishedEvent_Termination = 2 // 投了
+	PlayResponse_FinishedEvent_TIME_FORFEIT PlayResponse_FinishedEvent_Termination = 3 // 時間切れ
+	PlayResponse_FinishedEvent_DRAW_AGREED  PlayResponse_FinishedEvent_Termination = 4 // 合意による引き分け
+	PlayResponse_FinishedEvent_ABORTED      PlayResponse_FinishedEvent_Termination = 5 // 初手の前に中止
+	PlayResponse_FinishedEvent_ABANDONED    PlayResponse_FinishedEvent_Termination = 6 // 切断したまま戻らなかった
+)
+
+// Enum value maps for PlayResponse_FinishedEvent_Termination.
+var (
+	PlayResponse_FinishedEvent_Termination_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "COMPLETED",
+		2: "RESIGNED",
+		3: "TIME_FORFEIT",
+		4: "DRAW_AGREED",
+		5: "ABORTED",
+		6: "ABANDONED",
+	}
+	PlayResponse_FinishedEvent_Termination_value = map[string]int32{
+		"UNKNOWN":      0,
+		"COMPLETED":    1,
+		"RESIGNED":     2,
+		"TIME_FORFEIT": 3,
+		"DRAW_AGREED":  4,
+		"ABORTED":      5,
+		"ABANDONED":    6,
+	}
+)
+
+func (x PlayResponse_FinishedEvent_Termination) Enum() *PlayResponse_FinishedEvent_Termination {
+	p := new(PlayResponse_FinishedEvent_Termination)
+	*p = x
+	return p
+}
+
+func (x PlayResponse_FinishedEvent_Termination) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PlayResponse_FinishedEvent_Termination) Descriptor() protoreflect.EnumDescriptor {
+	return file_game_proto_enumTypes[0].Descriptor()
+}
+
+func (PlayResponse_FinishedEvent_Termination) Type() protoreflect.EnumType {
+	return &file_game_proto_enumTypes[0]
+}
+
+func (x PlayResponse_FinishedEvent_Termination) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PlayResponse_FinishedEvent_Termination.Descriptor instead.
+func (PlayResponse_FinishedEvent_Termination) EnumDescriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 4, 0}
+}
+
+type PlayResponse_ErrorEvent_Code int32
+
+const (
+	PlayResponse_ErrorEvent_UNKNOWN         PlayResponse_ErrorEvent_Code = 0
+	PlayResponse_ErrorEvent_NOT_YOUR_TURN   PlayResponse_ErrorEvent_Code = 1 // 手番ではない
+	PlayResponse_ErrorEvent_INVALID_PLAYER  PlayResponse_ErrorEvent_Code = 2 // 着席していない、または他のプレイヤーになりすました
+	PlayResponse_ErrorEvent_INVALID_ACTION  PlayResponse_ErrorEvent_Code = 3 // 現在の状態では受け付けられない操作
+	PlayResponse_ErrorEvent_ILLEGAL_MOVE    PlayResponse_ErrorEvent_Code = 4 // 石を置けない場所に置こうとした
+	PlayResponse_ErrorEvent_UNKNOWN_ROOM    PlayResponse_ErrorEvent_Code = 5 // 部屋がない。片付けられた部屋も含む
+	PlayResponse_ErrorEvent_GAME_FINISHED   PlayResponse_ErrorEvent_Code = 6 // 対局はすでに終了している
+	PlayResponse_ErrorEvent_UNAUTHENTICATED PlayResponse_ErrorEvent_Code = 7 // トークンが送られていない
+)
+
+// Enum value maps for PlayResponse_ErrorEvent_Code.
+var (
+	PlayResponse_ErrorEvent_Code_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "NOT_YOUR_TURN",
+		2: "INVALID_PLAYER",
+		3: "INVALID_ACTION",
+		4: "ILLEGAL_MOVE",
+		5: "UNKNOWN_ROOM",
+		6: "GAME_FINISHED",
+		7: "UNAUTHENTICATED",
+	}
+	PlayResponse_ErrorEvent_Code_value = map[string]int32{
+		"UNKNOWN":         0,
+		"NOT_YOUR_TURN":   1,
+		"INVALID_PLAYER":  2,
+		"INVALID_ACTION":  3,
+		"ILLEGAL_MOVE":    4,
+		"UNKNOWN_ROOM":    5,
+		"GAME_FINISHED":   6,
+		"UNAUTHENTICATED": 7,
+	}
+)
+
+func (x PlayResponse_ErrorEvent_Code) Enum() *PlayResponse_ErrorEvent_Code {
+	p := new(PlayResponse_ErrorEvent_Code)
+	*p = x
+	return p
+}
+
+func (x PlayResponse_ErrorEvent_Code) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PlayResponse_ErrorEvent_Code) Descriptor() protoreflect.EnumDescriptor {
+	return file_game_proto_enumTypes[1].Descriptor()
+}
+
+func (PlayResponse_ErrorEvent_Code) Type() protoreflect.EnumType {
+	return &file_game_proto_enumTypes[1]
+}
+
+func (x PlayResponse_ErrorEvent_Code) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PlayResponse_ErrorEvent_Code.Descriptor instead.
+func (PlayResponse_ErrorEvent_Code) EnumDescriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 11, 0}
+}
+
 type PlayRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RoomId int32   `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	Player *Player `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	// 観戦する部屋のID。着席する部屋とプレイヤーはmetadataのトークン(authorization: Bearer <token>)から決める
+	RoomId string `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// Deprecated: Marked as deprecated in game.proto.
+	Player *Player `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"` // 使わない。プレイヤーはトークンから決める
 	// Types that are assignable to Action:
 	//	*PlayRequest_Start
 	//	*PlayRequest_Move
+	//	*PlayRequest_Takeback
+	//	*PlayRequest_TakebackReply
+	//	*PlayRequest_Resume
+	//	*PlayRequest_Watch
+	//	*PlayRequest_Resign
+	//	*PlayRequest_OfferDraw
+	//	*PlayRequest_DrawReply
+	//	*PlayRequest_Abort
 	Action isPlayRequest_Action `protobuf_oneof:"action"`
 }
 
@@ -65,13 +200,14 @@ func (*PlayRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *PlayRequest) GetRoomId() int32 {
+func (x *PlayRequest) GetRoomId() string {
 	if x != nil {
 		return x.RoomId
 	}
-	return 0
+	return ""
 }
 
+// Deprecated: Marked as deprecated in game.proto.
 func (x *PlayRequest) GetPlayer() *Player {
 	if x != nil {
 		return x.Player
@@ -100,6 +236,62 @@ func (x *PlayRequest) GetMove() *MoveAction {
 	return nil
 }
 
+func (x *PlayRequest) GetTakeback() *TakebackAction {
+	if x, ok := x.GetAction().(*PlayRequest_Takeback); ok {
+		return x.Takeback
+	}
+	return nil
+}
+
+func (x *PlayRequest) GetTakebackReply() *TakebackReplyAction {
+	if x, ok := x.GetAction().(*PlayRequest_TakebackReply); ok {
+		return x.TakebackReply
+	}
+	return nil
+}
+
+func (x *PlayRequest) GetResume() *ResumeAction {
+	if x, ok := x.GetAction().(*PlayRequest_Resume); ok {
+		return x.Resume
+	}
+	return nil
+}
+
+func (x *PlayRequest) GetWatch() *WatchAction {
+	if x, ok := x.GetAction().(*PlayRequest_Watch); ok {
+		return x.Watch
+	}
+	return nil
+}
+
+func (x *PlayRequest) GetResign() *ResignAction {
+	if x, ok := x.GetAction().(*PlayRequest_Resign); ok {
+		return x.Resign
+	}
+	return nil
+}
+
+func (x *PlayRequest) GetOfferDraw() *OfferDrawAction {
+	if x, ok := x.GetAction().(*PlayRequest_OfferDraw); ok {
+		return x.OfferDraw
+	}
+	return nil
+}
+
+func (x *PlayRequest) GetDrawReply() *DrawReplyAction {
+	if x, ok := x.GetAction().(*PlayRequest_DrawReply); ok {
+		return x.DrawReply
+	}
+	return nil
+}
+
+func (x *PlayRequest) GetAbort() *AbortAction {
+	if x, ok := x.GetAction().(*PlayRequest_Abort); ok {
+		return x.Abort
+	}
+	return nil
+}
+
 type isPlayRequest_Action interface {
 	isPlayRequest_Action()
 }
@@ -112,18 +304,69 @@ type PlayRequest_Move struct {
 	Move *MoveAction `protobuf:"bytes,4,opt,name=move,proto3,oneof"`
 }
 
+type PlayRequest_Takeback struct {
+	Takeback *TakebackAction `protobuf:"bytes,5,opt,name=takeback,proto3,oneof"`
+}
+
+type PlayRequest_TakebackReply struct {
+	TakebackReply *TakebackReplyAction `protobuf:"bytes,6,opt,name=takeback_reply,json=takebackReply,proto3,oneof"`
+}
+
+type PlayRequest_Resume struct {
+	Resume *ResumeAction `protobuf:"bytes,7,opt,name=resume,proto3,oneof"`
+}
+
+type PlayRequest_Watch struct {
+	Watch *WatchAction `protobuf:"bytes,8,opt,name=watch,proto3,oneof"`
+}
+
+type PlayRequest_Resign struct {
+	Resign *ResignAction `protobuf:"bytes,9,opt,name=resign,proto3,oneof"`
+}
+
+type PlayRequest_OfferDraw struct {
+	OfferDraw *OfferDrawAction `protobuf:"bytes,10,opt,name=offer_draw,json=offerDraw,proto3,oneof"`
+}
+
+type PlayRequest_DrawReply struct {
+	DrawReply *DrawReplyAction `protobuf:"bytes,11,opt,name=draw_reply,json=drawReply,proto3,oneof"`
+}
+
+type PlayRequest_Abort struct {
+	Abort *AbortAction `protobuf:"bytes,12,opt,name=abort,proto3,oneof"`
+}
+
 func (*PlayRequest_Start) isPlayRequest_Action() {}
 
 func (*PlayRequest_Move) isPlayRequest_Action() {}
 
-type StartAction struct {
+func (*PlayRequest_Takeback) isPlayRequest_Action() {}
+
+func (*PlayRequest_TakebackReply) isPlayRequest_Action() {}
+
+func (*PlayRequest_Resume) isPlayRequest_Action() {}
+
+func (*PlayRequest_Watch) isPlayRequest_Action() {}
+
+func (*PlayRequest_Resign) isPlayRequest_Action() {}
+
+func (*PlayRequest_OfferDraw) isPlayRequest_Action() {}
+
+func (*PlayRequest_DrawReply) isPlayRequest_Action() {}
+
+func (*PlayRequest_Abort) isPlayRequest_Action() {}
+
+type Move struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	X int32 `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y int32 `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
 }
 
-func (x *StartAction) Reset() {
-	*x = StartAction{}
+func (x *Move) Reset() {
+	*x = Move{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_game_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -131,13 +374,13 @@ func (x *StartAction) Reset() {
 	}
 }
 
-func (x *StartAction) String() string {
+func (x *Move) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartAction) ProtoMessage() {}
+func (*Move) ProtoMessage() {}
 
-func (x *StartAction) ProtoReflect() protoreflect.Message {
+func (x *Move) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -149,21 +392,38 @@ func (x *StartAction) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartAction.ProtoReflect.Descriptor instead.
-func (*StartAction) Descriptor() ([]byte, []int) {
+// Deprecated: Use Move.ProtoReflect.Descriptor instead.
+func (*Move) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{1}
 }
 
-type MoveAction struct {
+func (x *Move) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Move) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// 棋譜の1手。パスの場合はpassがtrueでmoveは使わない
+type Ply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Move *Move `protobuf:"bytes,1,opt,name=move,proto3" json:"move,omitempty"`
+	Character Character `protobuf:"varint,1,opt,name=character,proto3,enum=game.Character" json:"character,omitempty"`
+	Move      *Move     `protobuf:"bytes,2,opt,name=move,proto3" json:"move,omitempty"`
+	Pass      bool      `protobuf:"varint,3,opt,name=pass,proto3" json:"pass,omitempty"`
 }
 
-func (x *MoveAction) Reset() {
-	*x = MoveAction{}
+func (x *Ply) Reset() {
+	*x = Ply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_game_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -171,13 +431,13 @@ func (x *MoveAction) Reset() {
 	}
 }
 
-func (x *MoveAction) String() string {
+func (x *Ply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MoveAction) ProtoMessage() {}
+func (*Ply) ProtoMessage() {}
 
-func (x *MoveAction) ProtoReflect() protoreflect.Message {
+func (x *Ply) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -189,33 +449,40 @@ func (x *MoveAction) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MoveAction.ProtoReflect.Descriptor instead.
-func (*MoveAction) Descriptor() ([]byte, []int) {
+// Deprecated: Use Ply.ProtoReflect.Descriptor instead.
+func (*Ply) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *MoveAction) GetMove() *Move {
+func (x *Ply) GetCharacter() Character {
+	if x != nil {
+		return x.Character
+	}
+	return Character_UNKNOWN
+}
+
+func (x *Ply) GetMove() *Move {
 	if x != nil {
 		return x.Move
 	}
 	return nil
 }
 
-type PlayResponse struct {
+func (x *Ply) GetPass() bool {
+	if x != nil {
+		return x.Pass
+	}
+	return false
+}
+
+type StartAction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	// Types that are assignable to Event:
-	//	*PlayResponse_Waiting
-	//	*PlayResponse_Ready
-	//	*PlayResponse_Move
-	//	*PlayResponse_Finished
-	Event isPlayResponse_Event `protobuf_oneof:"event"`
 }
 
-func (x *PlayResponse) Reset() {
-	*x = PlayResponse{}
+func (x *StartAction) Reset() {
+	*x = StartAction{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_game_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -223,13 +490,13 @@ func (x *PlayResponse) Reset() {
 	}
 }
 
-func (x *PlayResponse) String() string {
+func (x *StartAction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayResponse) ProtoMessage() {}
+func (*StartAction) ProtoMessage() {}
 
-func (x *PlayResponse) ProtoReflect() protoreflect.Message {
+func (x *StartAction) ProtoReflect() protoreflect.Message {
 	mi := &file_game_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -241,100 +508,86 @@ func (x *PlayResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayResponse.ProtoReflect.Descriptor instead.
-func (*PlayResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartAction.ProtoReflect.Descriptor instead.
+func (*StartAction) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{3}
 }
 
-func (m *PlayResponse) GetEvent() isPlayResponse_Event {
-	if m != nil {
-		return m.Event
-	}
-	return nil
-}
-
-func (x *PlayResponse) GetWaiting() *PlayResponse_WaitingEvent {
-	if x, ok := x.GetEvent().(*PlayResponse_Waiting); ok {
-		return x.Waiting
-	}
-	return nil
-}
-
-func (x *PlayResponse) GetReady() *PlayResponse_ReadyEvent {
-	if x, ok := x.GetEvent().(*PlayResponse_Ready); ok {
-		return x.Ready
-	}
-	return nil
-}
+// 通信が切れた後、マッチング時に発行されたトークンで元の席に戻る
+// metadataのトークンのプレイヤーの席に戻る
+type ResumeAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *PlayResponse) GetMove() *PlayResponse_MoveEvent {
-	if x, ok := x.GetEvent().(*PlayResponse_Move); ok {
-		return x.Move
-	}
-	return nil
+	// Deprecated: Marked as deprecated in game.proto.
+	SessionToken string `protobuf:"bytes,1,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"` // 使わない。metadataのトークンを使う
 }
 
-func (x *PlayResponse) GetFinished() *PlayResponse_FinishedEvent {
-	if x, ok := x.GetEvent().(*PlayResponse_Finished); ok {
-		return x.Finished
+func (x *ResumeAction) Reset() {
+	*x = ResumeAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-type isPlayResponse_Event interface {
-	isPlayResponse_Event()
+func (x *ResumeAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type PlayResponse_Waiting struct {
-	Waiting *PlayResponse_WaitingEvent `protobuf:"bytes,1,opt,name=waiting,proto3,oneof"`
-}
+func (*ResumeAction) ProtoMessage() {}
 
-type PlayResponse_Ready struct {
-	Ready *PlayResponse_ReadyEvent `protobuf:"bytes,2,opt,name=ready,proto3,oneof"`
+func (x *ResumeAction) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type PlayResponse_Move struct {
-	Move *PlayResponse_MoveEvent `protobuf:"bytes,3,opt,name=move,proto3,oneof"`
+// Deprecated: Use ResumeAction.ProtoReflect.Descriptor instead.
+func (*ResumeAction) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{4}
 }
 
-type PlayResponse_Finished struct {
-	Finished *PlayResponse_FinishedEvent `protobuf:"bytes,4,opt,name=finished,proto3,oneof"`
+// Deprecated: Marked as deprecated in game.proto.
+func (x *ResumeAction) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
 }
 
-func (*PlayResponse_Waiting) isPlayResponse_Event() {}
-
-func (*PlayResponse_Ready) isPlayResponse_Event() {}
-
-func (*PlayResponse_Move) isPlayResponse_Event() {}
-
-func (*PlayResponse_Finished) isPlayResponse_Event() {}
-
-type Move struct {
+// room_idの部屋を観戦する。観戦者は手を打つことはできず、対局の状態を受け取るのみ
+type WatchAction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	X int32 `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
-	Y int32 `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
 }
 
-func (x *Move) Reset() {
-	*x = Move{}
+func (x *WatchAction) Reset() {
+	*x = WatchAction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_game_proto_msgTypes[4]
+		mi := &file_game_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Move) String() string {
+func (x *WatchAction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Move) ProtoMessage() {}
+func (*WatchAction) ProtoMessage() {}
 
-func (x *Move) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[4]
+func (x *WatchAction) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -345,51 +598,870 @@ func (x *Move) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Move.ProtoReflect.Descriptor instead.
-func (*Move) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use WatchAction.ProtoReflect.Descriptor instead.
+func (*WatchAction) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{5}
+}
+
+type MoveAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Move *Move `protobuf:"bytes,1,opt,name=move,proto3" json:"move,omitempty"`
+}
+
+func (x *MoveAction) Reset() {
+	*x = MoveAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveAction) ProtoMessage() {}
+
+func (x *MoveAction) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveAction.ProtoReflect.Descriptor instead.
+func (*MoveAction) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MoveAction) GetMove() *Move {
+	if x != nil {
+		return x.Move
+	}
+	return nil
+}
+
+// 待ったの申し込み。相手が承諾すると、自分が最後に打った手の直前まで戻る
+type TakebackAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TakebackAction) Reset() {
+	*x = TakebackAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TakebackAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakebackAction) ProtoMessage() {}
+
+func (x *TakebackAction) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakebackAction.ProtoReflect.Descriptor instead.
+func (*TakebackAction) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{7}
+}
+
+// 相手からの待ったの申し込みへの返答
+type TakebackReplyAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accept bool `protobuf:"varint,1,opt,name=accept,proto3" json:"accept,omitempty"`
+}
+
+func (x *TakebackReplyAction) Reset() {
+	*x = TakebackReplyAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TakebackReplyAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakebackReplyAction) ProtoMessage() {}
+
+func (x *TakebackReplyAction) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakebackReplyAction.ProtoReflect.Descriptor instead.
+func (*TakebackReplyAction) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TakebackReplyAction) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+// 投了する
+type ResignAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ResignAction) Reset() {
+	*x = ResignAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResignAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResignAction) ProtoMessage() {}
+
+func (x *ResignAction) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResignAction.ProtoReflect.Descriptor instead.
+func (*ResignAction) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{9}
+}
+
+// 引き分けを申し込む。相手が次の手を打つと取り下げられる
+type OfferDrawAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *OfferDrawAction) Reset() {
+	*x = OfferDrawAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfferDrawAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferDrawAction) ProtoMessage() {}
+
+func (x *OfferDrawAction) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferDrawAction.ProtoReflect.Descriptor instead.
+func (*OfferDrawAction) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{10}
+}
+
+// 相手からの引き分けの申し込みへの返答
+type DrawReplyAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accept bool `protobuf:"varint,1,opt,name=accept,proto3" json:"accept,omitempty"`
+}
+
+func (x *DrawReplyAction) Reset() {
+	*x = DrawReplyAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrawReplyAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrawReplyAction) ProtoMessage() {}
+
+func (x *DrawReplyAction) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrawReplyAction.ProtoReflect.Descriptor instead.
+func (*DrawReplyAction) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DrawReplyAction) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+// 対局を中止する。自分の初手を打つ前のみ可能で、勝敗はつかない
+type AbortAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AbortAction) Reset() {
+	*x = AbortAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AbortAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortAction) ProtoMessage() {}
+
+func (x *AbortAction) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortAction.ProtoReflect.Descriptor instead.
+func (*AbortAction) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{12}
+}
+
+type PlayResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*PlayResponse_Waiting
+	//	*PlayResponse_Ready
+	//	*PlayResponse_Move
+	//	*PlayResponse_Finished
+	//	*PlayResponse_Error
+	//	*PlayResponse_Pass
+	//	*PlayResponse_TakebackRequested
+	//	*PlayResponse_Takeback
+	//	*PlayResponse_Snapshot
+	//	*PlayResponse_Clock
+	//	*PlayResponse_DrawOffered
+	//	*PlayResponse_DrawDeclined
+	Event isPlayResponse_Event `protobuf_oneof:"event"`
+}
+
+func (x *PlayResponse) Reset() {
+	*x = PlayResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayResponse) ProtoMessage() {}
+
+func (x *PlayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayResponse.ProtoReflect.Descriptor instead.
+func (*PlayResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13}
+}
+
+func (m *PlayResponse) GetEvent() isPlayResponse_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *PlayResponse) GetWaiting() *PlayResponse_WaitingEvent {
+	if x, ok := x.GetEvent().(*PlayResponse_Waiting); ok {
+		return x.Waiting
+	}
+	return nil
+}
+
+func (x *PlayResponse) GetReady() *PlayResponse_ReadyEvent {
+	if x, ok := x.GetEvent().(*PlayResponse_Ready); ok {
+		return x.Ready
+	}
+	return nil
+}
+
+func (x *PlayResponse) GetMove() *PlayResponse_MoveEvent {
+	if x, ok := x.GetEvent().(*PlayResponse_Move); ok {
+		return x.Move
+	}
+	return nil
+}
+
+func (x *PlayResponse) GetFinished() *PlayResponse_FinishedEvent {
+	if x, ok := x.GetEvent().(*PlayResponse_Finished); ok {
+		return x.Finished
+	}
+	return nil
+}
+
+func (x *PlayResponse) GetError() *PlayResponse_ErrorEvent {
+	if x, ok := x.GetEvent().(*PlayResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *PlayResponse) GetPass() *PlayResponse_PassEvent {
+	if x, ok := x.GetEvent().(*PlayResponse_Pass); ok {
+		return x.Pass
+	}
+	return nil
+}
+
+func (x *PlayResponse) GetTakebackRequested() *PlayResponse_TakebackRequestedEvent {
+	if x, ok := x.GetEvent().(*PlayResponse_TakebackRequested); ok {
+		return x.TakebackRequested
+	}
+	return nil
+}
+
+func (x *PlayResponse) GetTakeback() *PlayResponse_TakebackEvent {
+	if x, ok := x.GetEvent().(*PlayResponse_Takeback); ok {
+		return x.Takeback
+	}
+	return nil
+}
+
+func (x *PlayResponse) GetSnapshot() *PlayResponse_SnapshotEvent {
+	if x, ok := x.GetEvent().(*PlayResponse_Snapshot); ok {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *PlayResponse) GetClock() *PlayResponse_ClockEvent {
+	if x, ok := x.GetEvent().(*PlayResponse_Clock); ok {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *PlayResponse) GetDrawOffered() *PlayResponse_DrawOfferedEvent {
+	if x, ok := x.GetEvent().(*PlayResponse_DrawOffered); ok {
+		return x.DrawOffered
+	}
+	return nil
+}
+
+func (x *PlayResponse) GetDrawDeclined() *PlayResponse_DrawDeclinedEvent {
+	if x, ok := x.GetEvent().(*PlayResponse_DrawDeclined); ok {
+		return x.DrawDeclined
+	}
+	return nil
+}
+
+type isPlayResponse_Event interface {
+	isPlayResponse_Event()
+}
+
+type PlayResponse_Waiting struct {
+	Waiting *PlayResponse_WaitingEvent `protobuf:"bytes,1,opt,name=waiting,proto3,oneof"`
+}
+
+type PlayResponse_Ready struct {
+	Ready *PlayResponse_ReadyEvent `protobuf:"bytes,2,opt,name=ready,proto3,oneof"`
+}
+
+type PlayResponse_Move struct {
+	Move *PlayResponse_MoveEvent `protobuf:"bytes,3,opt,name=move,proto3,oneof"`
+}
+
+type PlayResponse_Finished struct {
+	Finished *PlayResponse_FinishedEvent `protobuf:"bytes,4,opt,name=finished,proto3,oneof"`
+}
+
+type PlayResponse_Error struct {
+	Error *PlayResponse_ErrorEvent `protobuf:"bytes,5,opt,name=error,proto3,oneof"`
+}
+
+type PlayResponse_Pass struct {
+	Pass *PlayResponse_PassEvent `protobuf:"bytes,6,opt,name=pass,proto3,oneof"`
+}
+
+type PlayResponse_TakebackRequested struct {
+	TakebackRequested *PlayResponse_TakebackRequestedEvent `protobuf:"bytes,7,opt,name=takeback_requested,json=takebackRequested,proto3,oneof"`
+}
+
+type PlayResponse_Takeback struct {
+	Takeback *PlayResponse_TakebackEvent `protobuf:"bytes,8,opt,name=takeback,proto3,oneof"`
+}
+
+type PlayResponse_Snapshot struct {
+	Snapshot *PlayResponse_SnapshotEvent `protobuf:"bytes,9,opt,name=snapshot,proto3,oneof"`
+}
+
+type PlayResponse_Clock struct {
+	Clock *PlayResponse_ClockEvent `protobuf:"bytes,10,opt,name=clock,proto3,oneof"`
+}
+
+type PlayResponse_DrawOffered struct {
+	DrawOffered *PlayResponse_DrawOfferedEvent `protobuf:"bytes,11,opt,name=draw_offered,json=drawOffered,proto3,oneof"`
+}
+
+type PlayResponse_DrawDeclined struct {
+	DrawDeclined *PlayResponse_DrawDeclinedEvent `protobuf:"bytes,12,opt,name=draw_declined,json=drawDeclined,proto3,oneof"`
+}
+
+func (*PlayResponse_Waiting) isPlayResponse_Event() {}
+
+func (*PlayResponse_Ready) isPlayResponse_Event() {}
+
+func (*PlayResponse_Move) isPlayResponse_Event() {}
+
+func (*PlayResponse_Finished) isPlayResponse_Event() {}
+
+func (*PlayResponse_Error) isPlayResponse_Event() {}
+
+func (*PlayResponse_Pass) isPlayResponse_Event() {}
+
+func (*PlayResponse_TakebackRequested) isPlayResponse_Event() {}
+
+func (*PlayResponse_Takeback) isPlayResponse_Event() {}
+
+func (*PlayResponse_Snapshot) isPlayResponse_Event() {}
+
+func (*PlayResponse_Clock) isPlayResponse_Event() {}
+
+func (*PlayResponse_DrawOffered) isPlayResponse_Event() {}
+
+func (*PlayResponse_DrawDeclined) isPlayResponse_Event() {}
+
+// 対局者ごとの残り時間
+type Clocks struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Black   *Clock    `protobuf:"bytes,1,opt,name=black,proto3" json:"black,omitempty"`
+	White   *Clock    `protobuf:"bytes,2,opt,name=white,proto3" json:"white,omitempty"`
+	Running Character `protobuf:"varint,3,opt,name=running,proto3,enum=game.Character" json:"running,omitempty"` // 時計が進んでいる色
+}
+
+func (x *Clocks) Reset() {
+	*x = Clocks{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Clocks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Clocks) ProtoMessage() {}
+
+func (x *Clocks) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Clocks.ProtoReflect.Descriptor instead.
+func (*Clocks) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Clocks) GetBlack() *Clock {
+	if x != nil {
+		return x.Black
+	}
+	return nil
+}
+
+func (x *Clocks) GetWhite() *Clock {
+	if x != nil {
+		return x.White
+	}
+	return nil
+}
+
+func (x *Clocks) GetRunning() Character {
+	if x != nil {
+		return x.Running
+	}
+	return Character_UNKNOWN
+}
+
+type Clock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RemainingMs int64 `protobuf:"varint,1,opt,name=remaining_ms,json=remainingMs,proto3" json:"remaining_ms,omitempty"` // 残り時間。秒読み中はその手に使える残り時間
+	Byoyomi     bool  `protobuf:"varint,2,opt,name=byoyomi,proto3" json:"byoyomi,omitempty"`                            // 秒読みに入っている
+}
+
+func (x *Clock) Reset() {
+	*x = Clock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Clock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Clock) ProtoMessage() {}
+
+func (x *Clock) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Clock.ProtoReflect.Descriptor instead.
+func (*Clock) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Clock) GetRemainingMs() int64 {
+	if x != nil {
+		return x.RemainingMs
+	}
+	return 0
+}
+
+func (x *Clock) GetByoyomi() bool {
+	if x != nil {
+		return x.Byoyomi
+	}
+	return false
+}
+
+// protobufでは二次元配列を定義するためにrepeatedを持つmessageをfieldでrepeatedする必要がある。
+type Board struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cols []*Board_Col `protobuf:"bytes,1,rep,name=cols,proto3" json:"cols,omitempty"`
+}
+
+func (x *Board) Reset() {
+	*x = Board{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Board) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Board) ProtoMessage() {}
+
+func (x *Board) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Board.ProtoReflect.Descriptor instead.
+func (*Board) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Board) GetCols() []*Board_Col {
+	if x != nil {
+		return x.Cols
+	}
+	return nil
+}
+
+type PlayResponse_WaitingEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PlayResponse_WaitingEvent) Reset() {
+	*x = PlayResponse_WaitingEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayResponse_WaitingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayResponse_WaitingEvent) ProtoMessage() {}
+
+func (x *PlayResponse_WaitingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayResponse_WaitingEvent.ProtoReflect.Descriptor instead.
+func (*PlayResponse_WaitingEvent) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 0}
+}
+
+type PlayResponse_ReadyEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PlayResponse_ReadyEvent) Reset() {
+	*x = PlayResponse_ReadyEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayResponse_ReadyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayResponse_ReadyEvent) ProtoMessage() {}
+
+func (x *PlayResponse_ReadyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayResponse_ReadyEvent.ProtoReflect.Descriptor instead.
+func (*PlayResponse_ReadyEvent) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 1}
+}
+
+type PlayResponse_MoveEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Player *Player `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Move   *Move   `protobuf:"bytes,2,opt,name=move,proto3" json:"move,omitempty"`
+	Board  *Board  `protobuf:"bytes,3,opt,name=board,proto3" json:"board,omitempty"`
+	Clocks *Clocks `protobuf:"bytes,4,opt,name=clocks,proto3" json:"clocks,omitempty"` // 手を打った後の残り時間。持ち時間がない部屋では空
+}
+
+func (x *PlayResponse_MoveEvent) Reset() {
+	*x = PlayResponse_MoveEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayResponse_MoveEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayResponse_MoveEvent) ProtoMessage() {}
+
+func (x *PlayResponse_MoveEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *Move) GetX() int32 {
+// Deprecated: Use PlayResponse_MoveEvent.ProtoReflect.Descriptor instead.
+func (*PlayResponse_MoveEvent) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 2}
+}
+
+func (x *PlayResponse_MoveEvent) GetPlayer() *Player {
 	if x != nil {
-		return x.X
+		return x.Player
 	}
-	return 0
+	return nil
 }
 
-func (x *Move) GetY() int32 {
+func (x *PlayResponse_MoveEvent) GetMove() *Move {
 	if x != nil {
-		return x.Y
+		return x.Move
 	}
-	return 0
+	return nil
 }
 
-// protobufでは二次元配列を定義するためにrepeatedを持つmessageをfieldでrepeatedする必要がある。
-type Board struct {
+func (x *PlayResponse_MoveEvent) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *PlayResponse_MoveEvent) GetClocks() *Clocks {
+	if x != nil {
+		return x.Clocks
+	}
+	return nil
+}
+
+// 持ち時間がある部屋で、対局中に一定間隔で送る残り時間
+type PlayResponse_ClockEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Cols []*Board_Col `protobuf:"bytes,1,rep,name=cols,proto3" json:"cols,omitempty"`
+	Clocks *Clocks `protobuf:"bytes,1,opt,name=clocks,proto3" json:"clocks,omitempty"`
 }
 
-func (x *Board) Reset() {
-	*x = Board{}
+func (x *PlayResponse_ClockEvent) Reset() {
+	*x = PlayResponse_ClockEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_game_proto_msgTypes[5]
+		mi := &file_game_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Board) String() string {
+func (x *PlayResponse_ClockEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Board) ProtoMessage() {}
+func (*PlayResponse_ClockEvent) ProtoMessage() {}
 
-func (x *Board) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[5]
+func (x *PlayResponse_ClockEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -400,41 +1472,47 @@ func (x *Board) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Board.ProtoReflect.Descriptor instead.
-func (*Board) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use PlayResponse_ClockEvent.ProtoReflect.Descriptor instead.
+func (*PlayResponse_ClockEvent) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 3}
 }
 
-func (x *Board) GetCols() []*Board_Col {
+func (x *PlayResponse_ClockEvent) GetClocks() *Clocks {
 	if x != nil {
-		return x.Cols
+		return x.Clocks
 	}
 	return nil
 }
 
-type PlayResponse_WaitingEvent struct {
+type PlayResponse_FinishedEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Winner      Character                              `protobuf:"varint,1,opt,name=winner,proto3,enum=game.Character" json:"winner,omitempty"` // 引き分けや中止の場合はNONE
+	Board       *Board                                 `protobuf:"bytes,2,opt,name=board,proto3" json:"board,omitempty"`
+	Termination PlayResponse_FinishedEvent_Termination `protobuf:"varint,3,opt,name=termination,proto3,enum=game.PlayResponse_FinishedEvent_Termination" json:"termination,omitempty"`
+	Loser       Character                              `protobuf:"varint,4,opt,name=loser,proto3,enum=game.Character" json:"loser,omitempty"` // 投了や時間切れの場合に負けた色
+	Reward      *Reward                                `protobuf:"bytes,5,opt,name=reward,proto3" json:"reward,omitempty"`                    // 勝ったプレイヤーに付与した報酬。引き分けや中止、AIが勝った場合は空
 }
 
-func (x *PlayResponse_WaitingEvent) Reset() {
-	*x = PlayResponse_WaitingEvent{}
+func (x *PlayResponse_FinishedEvent) Reset() {
+	*x = PlayResponse_FinishedEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_game_proto_msgTypes[6]
+		mi := &file_game_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PlayResponse_WaitingEvent) String() string {
+func (x *PlayResponse_FinishedEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayResponse_WaitingEvent) ProtoMessage() {}
+func (*PlayResponse_FinishedEvent) ProtoMessage() {}
 
-func (x *PlayResponse_WaitingEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[6]
+func (x *PlayResponse_FinishedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -445,34 +1523,295 @@ func (x *PlayResponse_WaitingEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayResponse_WaitingEvent.ProtoReflect.Descriptor instead.
-func (*PlayResponse_WaitingEvent) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{3, 0}
+// Deprecated: Use PlayResponse_FinishedEvent.ProtoReflect.Descriptor instead.
+func (*PlayResponse_FinishedEvent) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 4}
 }
 
-type PlayResponse_ReadyEvent struct {
+func (x *PlayResponse_FinishedEvent) GetWinner() Character {
+	if x != nil {
+		return x.Winner
+	}
+	return Character_UNKNOWN
+}
+
+func (x *PlayResponse_FinishedEvent) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *PlayResponse_FinishedEvent) GetTermination() PlayResponse_FinishedEvent_Termination {
+	if x != nil {
+		return x.Termination
+	}
+	return PlayResponse_FinishedEvent_UNKNOWN
+}
+
+func (x *PlayResponse_FinishedEvent) GetLoser() Character {
+	if x != nil {
+		return x.Loser
+	}
+	return Character_UNKNOWN
+}
+
+func (x *PlayResponse_FinishedEvent) GetReward() *Reward {
+	if x != nil {
+		return x.Reward
+	}
+	return nil
+}
+
+// 引き分けが申し込まれた。申し込んだプレイヤーの相手が返答する
+type PlayResponse_DrawOfferedEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Player *Player `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"` // 申し込んだプレイヤー
 }
 
-func (x *PlayResponse_ReadyEvent) Reset() {
-	*x = PlayResponse_ReadyEvent{}
+func (x *PlayResponse_DrawOfferedEvent) Reset() {
+	*x = PlayResponse_DrawOfferedEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_game_proto_msgTypes[7]
+		mi := &file_game_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PlayResponse_ReadyEvent) String() string {
+func (x *PlayResponse_DrawOfferedEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayResponse_ReadyEvent) ProtoMessage() {}
+func (*PlayResponse_DrawOfferedEvent) ProtoMessage() {}
 
-func (x *PlayResponse_ReadyEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[7]
+func (x *PlayResponse_DrawOfferedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayResponse_DrawOfferedEvent.ProtoReflect.Descriptor instead.
+func (*PlayResponse_DrawOfferedEvent) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 5}
+}
+
+func (x *PlayResponse_DrawOfferedEvent) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+// 引き分けの申し込みが断られた、または相手が手を打ったので取り下げられた。承諾された場合はFinishedEventが送られる
+type PlayResponse_DrawDeclinedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PlayResponse_DrawDeclinedEvent) Reset() {
+	*x = PlayResponse_DrawDeclinedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayResponse_DrawDeclinedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayResponse_DrawDeclinedEvent) ProtoMessage() {}
+
+func (x *PlayResponse_DrawDeclinedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayResponse_DrawDeclinedEvent.ProtoReflect.Descriptor instead.
+func (*PlayResponse_DrawDeclinedEvent) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 6}
+}
+
+// 置ける場所がなく、手番がパスされた
+type PlayResponse_PassEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Player *Player `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"` // パスしたプレイヤー
+}
+
+func (x *PlayResponse_PassEvent) Reset() {
+	*x = PlayResponse_PassEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayResponse_PassEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayResponse_PassEvent) ProtoMessage() {}
+
+func (x *PlayResponse_PassEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayResponse_PassEvent.ProtoReflect.Descriptor instead.
+func (*PlayResponse_PassEvent) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 7}
+}
+
+func (x *PlayResponse_PassEvent) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+// 再接続したクライアントと、観戦を始めたクライアントにのみ返却する、対局の現在の状態
+type PlayResponse_SnapshotEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Me      *Player   `protobuf:"bytes,1,opt,name=me,proto3" json:"me,omitempty"` // 観戦者の場合は空
+	Board   *Board    `protobuf:"bytes,2,opt,name=board,proto3" json:"board,omitempty"`
+	Turn    Character `protobuf:"varint,3,opt,name=turn,proto3,enum=game.Character" json:"turn,omitempty"` // 手番
+	Moves   []*Ply    `protobuf:"bytes,4,rep,name=moves,proto3" json:"moves,omitempty"`                    // 初手からの棋譜
+	Started bool      `protobuf:"varint,5,opt,name=started,proto3" json:"started,omitempty"`
+	Clocks  *Clocks   `protobuf:"bytes,6,opt,name=clocks,proto3" json:"clocks,omitempty"`
+}
+
+func (x *PlayResponse_SnapshotEvent) Reset() {
+	*x = PlayResponse_SnapshotEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayResponse_SnapshotEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayResponse_SnapshotEvent) ProtoMessage() {}
+
+func (x *PlayResponse_SnapshotEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayResponse_SnapshotEvent.ProtoReflect.Descriptor instead.
+func (*PlayResponse_SnapshotEvent) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 8}
+}
+
+func (x *PlayResponse_SnapshotEvent) GetMe() *Player {
+	if x != nil {
+		return x.Me
+	}
+	return nil
+}
+
+func (x *PlayResponse_SnapshotEvent) GetBoard() *Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *PlayResponse_SnapshotEvent) GetTurn() Character {
+	if x != nil {
+		return x.Turn
+	}
+	return Character_UNKNOWN
+}
+
+func (x *PlayResponse_SnapshotEvent) GetMoves() []*Ply {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *PlayResponse_SnapshotEvent) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+func (x *PlayResponse_SnapshotEvent) GetClocks() *Clocks {
+	if x != nil {
+		return x.Clocks
+	}
+	return nil
+}
+
+// 待ったが申し込まれた。申し込んだプレイヤーの相手が返答する
+type PlayResponse_TakebackRequestedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Player *Player `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"` // 申し込んだプレイヤー
+}
+
+func (x *PlayResponse_TakebackRequestedEvent) Reset() {
+	*x = PlayResponse_TakebackRequestedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_game_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayResponse_TakebackRequestedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayResponse_TakebackRequestedEvent) ProtoMessage() {}
+
+func (x *PlayResponse_TakebackRequestedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -483,38 +1822,46 @@ func (x *PlayResponse_ReadyEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayResponse_ReadyEvent.ProtoReflect.Descriptor instead.
-func (*PlayResponse_ReadyEvent) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{3, 1}
+// Deprecated: Use PlayResponse_TakebackRequestedEvent.ProtoReflect.Descriptor instead.
+func (*PlayResponse_TakebackRequestedEvent) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 9}
+}
+
+func (x *PlayResponse_TakebackRequestedEvent) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
 }
 
-type PlayResponse_MoveEvent struct {
+// 待ったへの返答。承諾された場合はplyの盤面まで戻る
+type PlayResponse_TakebackEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Player *Player `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
-	Move   *Move   `protobuf:"bytes,2,opt,name=move,proto3" json:"move,omitempty"`
-	Board  *Board  `protobuf:"bytes,3,opt,name=board,proto3" json:"board,omitempty"`
+	Accepted bool   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Ply      int32  `protobuf:"varint,2,opt,name=ply,proto3" json:"ply,omitempty"` // 戻った後の手数
+	Board    *Board `protobuf:"bytes,3,opt,name=board,proto3" json:"board,omitempty"`
 }
 
-func (x *PlayResponse_MoveEvent) Reset() {
-	*x = PlayResponse_MoveEvent{}
+func (x *PlayResponse_TakebackEvent) Reset() {
+	*x = PlayResponse_TakebackEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_game_proto_msgTypes[8]
+		mi := &file_game_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PlayResponse_MoveEvent) String() string {
+func (x *PlayResponse_TakebackEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayResponse_MoveEvent) ProtoMessage() {}
+func (*PlayResponse_TakebackEvent) ProtoMessage() {}
 
-func (x *PlayResponse_MoveEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[8]
+func (x *PlayResponse_TakebackEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -525,58 +1872,60 @@ func (x *PlayResponse_MoveEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayResponse_MoveEvent.ProtoReflect.Descriptor instead.
-func (*PlayResponse_MoveEvent) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{3, 2}
+// Deprecated: Use PlayResponse_TakebackEvent.ProtoReflect.Descriptor instead.
+func (*PlayResponse_TakebackEvent) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 10}
 }
 
-func (x *PlayResponse_MoveEvent) GetPlayer() *Player {
+func (x *PlayResponse_TakebackEvent) GetAccepted() bool {
 	if x != nil {
-		return x.Player
+		return x.Accepted
 	}
-	return nil
+	return false
 }
 
-func (x *PlayResponse_MoveEvent) GetMove() *Move {
+func (x *PlayResponse_TakebackEvent) GetPly() int32 {
 	if x != nil {
-		return x.Move
+		return x.Ply
 	}
-	return nil
+	return 0
 }
 
-func (x *PlayResponse_MoveEvent) GetBoard() *Board {
+func (x *PlayResponse_TakebackEvent) GetBoard() *Board {
 	if x != nil {
 		return x.Board
 	}
 	return nil
 }
 
-type PlayResponse_FinishedEvent struct {
+// リクエストを受け付けられなかった場合に、リクエストを送ったクライアントにのみ返却する。
+// streamは切らないので、クライアントはcodeを見て操作を直し、送り直せる
+type PlayResponse_ErrorEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Winner Character `protobuf:"varint,1,opt,name=winner,proto3,enum=game.Character" json:"winner,omitempty"`
-	Board  *Board    `protobuf:"bytes,2,opt,name=board,proto3" json:"board,omitempty"`
+	Code    PlayResponse_ErrorEvent_Code `protobuf:"varint,1,opt,name=code,proto3,enum=game.PlayResponse_ErrorEvent_Code" json:"code,omitempty"`
+	Message string                       `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-func (x *PlayResponse_FinishedEvent) Reset() {
-	*x = PlayResponse_FinishedEvent{}
+func (x *PlayResponse_ErrorEvent) Reset() {
+	*x = PlayResponse_ErrorEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_game_proto_msgTypes[9]
+		mi := &file_game_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PlayResponse_FinishedEvent) String() string {
+func (x *PlayResponse_ErrorEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayResponse_FinishedEvent) ProtoMessage() {}
+func (*PlayResponse_ErrorEvent) ProtoMessage() {}
 
-func (x *PlayResponse_FinishedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[9]
+func (x *PlayResponse_ErrorEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -587,23 +1936,23 @@ func (x *PlayResponse_FinishedEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayResponse_FinishedEvent.ProtoReflect.Descriptor instead.
-func (*PlayResponse_FinishedEvent) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{3, 3}
+// Deprecated: Use PlayResponse_ErrorEvent.ProtoReflect.Descriptor instead.
+func (*PlayResponse_ErrorEvent) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13, 11}
 }
 
-func (x *PlayResponse_FinishedEvent) GetWinner() Character {
+func (x *PlayResponse_ErrorEvent) GetCode() PlayResponse_ErrorEvent_Code {
 	if x != nil {
-		return x.Winner
+		return x.Code
 	}
-	return Character_UNKNOWN
+	return PlayResponse_ErrorEvent_UNKNOWN
 }
 
-func (x *PlayResponse_FinishedEvent) GetBoard() *Board {
+func (x *PlayResponse_ErrorEvent) GetMessage() string {
 	if x != nil {
-		return x.Board
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
 type Board_Col struct {
@@ -617,7 +1966,7 @@ type Board_Col struct {
 func (x *Board_Col) Reset() {
 	*x = Board_Col{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_game_proto_msgTypes[10]
+		mi := &file_game_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -630,7 +1979,7 @@ func (x *Board_Col) String() string {
 func (*Board_Col) ProtoMessage() {}
 
 func (x *Board_Col) ProtoReflect() protoreflect.Message {
-	mi := &file_game_proto_msgTypes[10]
+	mi := &file_game_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -643,7 +1992,7 @@ func (x *Board_Col) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Board_Col.ProtoReflect.Descriptor instead.
 func (*Board_Col) Descriptor() ([]byte, []int) {
-	return file_game_proto_rawDescGZIP(), []int{5, 0}
+	return file_game_proto_rawDescGZIP(), []int{16, 0}
 }
 
 func (x *Board_Col) GetCells() []Character {
@@ -659,21 +2008,71 @@ var file_game_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x67, 0x61,
 	0x6d, 0x65, 0x1a, 0x0c, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x1a, 0x0f, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0xa9, 0x01, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x06, 0x70, 0x6c,
-	0x61, 0x79, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61, 0x6d,
-	0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x12, 0x29, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x26, 0x0a, 0x04, 0x6d,
-	0x6f, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x61, 0x6d, 0x65,
-	0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x04, 0x6d,
-	0x6f, 0x76, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x0d, 0x0a,
-	0x0b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2c, 0x0a, 0x0a,
-	0x4d, 0x6f, 0x76, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x04, 0x6d, 0x6f,
-	0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e,
-	0x4d, 0x6f, 0x76, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0xf0, 0x03, 0x0a, 0x0c, 0x50,
+	0x6f, 0x1a, 0x0c, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0xc7, 0x04, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x6f, 0x6f, 0x6d, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x12, 0x29, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x26, 0x0a,
+	0x04, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
+	0x04, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x74, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63,
+	0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x54,
+	0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
+	0x08, 0x74, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x42, 0x0a, 0x0e, 0x74, 0x61, 0x6b,
+	0x65, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63,
+	0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0d,
+	0x74, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2c, 0x0a,
+	0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x67, 0x61, 0x6d, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x77,
+	0x61, 0x74, 0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
+	0x05, 0x77, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2c, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x52, 0x65,
+	0x73, 0x69, 0x67, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65,
+	0x73, 0x69, 0x67, 0x6e, 0x12, 0x36, 0x0a, 0x0a, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x64, 0x72,
+	0x61, 0x77, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e,
+	0x4f, 0x66, 0x66, 0x65, 0x72, 0x44, 0x72, 0x61, 0x77, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x00, 0x52, 0x09, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x44, 0x72, 0x61, 0x77, 0x12, 0x36, 0x0a, 0x0a,
+	0x64, 0x72, 0x61, 0x77, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x44, 0x72, 0x61, 0x77, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x09, 0x64, 0x72, 0x61, 0x77, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x41, 0x62, 0x6f, 0x72, 0x74,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x05, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x42,
+	0x08, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x22, 0x0a, 0x04, 0x4d, 0x6f, 0x76,
+	0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x78, 0x12,
+	0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01, 0x79, 0x22, 0x68, 0x0a,
+	0x03, 0x50, 0x6c, 0x79, 0x12, 0x2d, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43,
+	0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x52, 0x09, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63,
+	0x74, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0a, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x04, 0x6d,
+	0x6f, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x70, 0x61, 0x73, 0x73, 0x22, 0x0d, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x37, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18,
+	0x01, 0x52, 0x0c, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
+	0x0d, 0x0a, 0x0b, 0x57, 0x61, 0x74, 0x63, 0x68, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2c,
+	0x0a, 0x0a, 0x4d, 0x6f, 0x76, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x04,
+	0x6d, 0x6f, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0x10, 0x0a, 0x0e,
+	0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2d,
+	0x0a, 0x13, 0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x22, 0x0e, 0x0a,
+	0x0c, 0x52, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x11, 0x0a,
+	0x0f, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x44, 0x72, 0x61, 0x77, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x29, 0x0a, 0x0f, 0x44, 0x72, 0x61, 0x77, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x22, 0x0d, 0x0a, 0x0b, 0x41,
+	0x62, 0x6f, 0x72, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xf1, 0x10, 0x0a, 0x0c, 0x50,
 	0x6c, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x77,
 	0x61, 0x69, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67,
 	0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
@@ -689,36 +2088,149 @@ var file_game_proto_rawDesc = []byte{
 	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61,
 	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68,
 	0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73,
-	0x68, 0x65, 0x64, 0x1a, 0x0e, 0x0a, 0x0c, 0x57, 0x61, 0x69, 0x74, 0x69, 0x6e, 0x67, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x1a, 0x0c, 0x0a, 0x0a, 0x52, 0x65, 0x61, 0x64, 0x79, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x1a, 0x74, 0x0a, 0x09, 0x4d, 0x6f, 0x76, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x24,
+	0x68, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x32, 0x0a, 0x04, 0x70, 0x61,
+	0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e,
+	0x50, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x61, 0x73,
+	0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x04, 0x70, 0x61, 0x73, 0x73, 0x12, 0x5a,
+	0x0a, 0x12, 0x74, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x54,
+	0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x11, 0x74, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x3e, 0x0a, 0x08, 0x74, 0x61,
+	0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67,
+	0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00,
+	0x52, 0x08, 0x74, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x3e, 0x0a, 0x08, 0x73, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67,
+	0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00,
+	0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x35, 0x0a, 0x05, 0x63, 0x6c,
+	0x6f, 0x63, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x2e, 0x50, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6c,
+	0x6f, 0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x05, 0x63, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x48, 0x0a, 0x0c, 0x64, 0x72, 0x61, 0x77, 0x5f, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x65,
+	0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50,
+	0x6c, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x72, 0x61, 0x77,
+	0x4f, 0x66, 0x66, 0x65, 0x72, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0b,
+	0x64, 0x72, 0x61, 0x77, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x65, 0x64, 0x12, 0x4b, 0x0a, 0x0d, 0x64,
+	0x72, 0x61, 0x77, 0x5f, 0x64, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x72, 0x61, 0x77, 0x44, 0x65, 0x63, 0x6c, 0x69,
+	0x6e, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x72, 0x61, 0x77,
+	0x44, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65, 0x64, 0x1a, 0x0e, 0x0a, 0x0c, 0x57, 0x61, 0x69, 0x74,
+	0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x1a, 0x0c, 0x0a, 0x0a, 0x52, 0x65, 0x61, 0x64,
+	0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x1a, 0x9a, 0x01, 0x0a, 0x09, 0x4d, 0x6f, 0x76, 0x65, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x04, 0x6d, 0x6f,
+	0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e,
+	0x4d, 0x6f, 0x76, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x24, 0x0a,
+	0x06, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x06, 0x63, 0x6c, 0x6f,
+	0x63, 0x6b, 0x73, 0x1a, 0x32, 0x0a, 0x0a, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x24, 0x0a, 0x06, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52,
+	0x06, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x1a, 0xf0, 0x02, 0x0a, 0x0d, 0x46, 0x69, 0x6e, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x06, 0x77, 0x69, 0x6e,
+	0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x67, 0x61, 0x6d, 0x65,
+	0x2e, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e,
+	0x65, 0x72, 0x12, 0x21, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x4e, 0x0a, 0x0b, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46,
+	0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x65, 0x72,
+	0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x05, 0x6c, 0x6f, 0x73, 0x65, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x72,
+	0x61, 0x63, 0x74, 0x65, 0x72, 0x52, 0x05, 0x6c, 0x6f, 0x73, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x06,
+	0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67,
+	0x61, 0x6d, 0x65, 0x2e, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x77, 0x61,
+	0x72, 0x64, 0x22, 0x76, 0x0a, 0x0b, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0d,
+	0x0a, 0x09, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a,
+	0x08, 0x52, 0x45, 0x53, 0x49, 0x47, 0x4e, 0x45, 0x44, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x54,
+	0x49, 0x4d, 0x45, 0x5f, 0x46, 0x4f, 0x52, 0x46, 0x45, 0x49, 0x54, 0x10, 0x03, 0x12, 0x0f, 0x0a,
+	0x0b, 0x44, 0x52, 0x41, 0x57, 0x5f, 0x41, 0x47, 0x52, 0x45, 0x45, 0x44, 0x10, 0x04, 0x12, 0x0b,
+	0x0a, 0x07, 0x41, 0x42, 0x4f, 0x52, 0x54, 0x45, 0x44, 0x10, 0x05, 0x12, 0x0d, 0x0a, 0x09, 0x41,
+	0x42, 0x41, 0x4e, 0x44, 0x4f, 0x4e, 0x45, 0x44, 0x10, 0x06, 0x1a, 0x38, 0x0a, 0x10, 0x44, 0x72,
+	0x61, 0x77, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x24,
 	0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
 	0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x70, 0x6c,
-	0x61, 0x79, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x04,
-	0x6d, 0x6f, 0x76, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x03, 0x20,
+	0x61, 0x79, 0x65, 0x72, 0x1a, 0x13, 0x0a, 0x11, 0x44, 0x72, 0x61, 0x77, 0x44, 0x65, 0x63, 0x6c,
+	0x69, 0x6e, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x1a, 0x31, 0x0a, 0x09, 0x50, 0x61, 0x73,
+	0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x1a, 0xd6, 0x01, 0x0a,
+	0x0d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1c,
+	0x0a, 0x02, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x02, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x05,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x61,
+	0x6d, 0x65, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12,
+	0x23, 0x0a, 0x04, 0x74, 0x75, 0x72, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e,
+	0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x52, 0x04,
+	0x74, 0x75, 0x72, 0x6e, 0x12, 0x1f, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x79, 0x52, 0x05,
+	0x6d, 0x6f, 0x76, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12,
+	0x24, 0x0a, 0x06, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x52, 0x06, 0x63,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x1a, 0x3e, 0x0a, 0x16, 0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x24, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x06, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x1a, 0x60, 0x0a, 0x0d, 0x54, 0x61, 0x6b, 0x65, 0x62, 0x61, 0x63,
+	0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x65, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x70, 0x6c, 0x79, 0x12, 0x21, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x03, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64,
-	0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x1a, 0x5b, 0x0a, 0x0d, 0x46, 0x69, 0x6e, 0x69, 0x73,
-	0x68, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e,
-	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e,
-	0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65,
-	0x72, 0x12, 0x21, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0b, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x05, 0x62,
-	0x6f, 0x61, 0x72, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x22, 0x0a,
-	0x04, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x01,
-	0x79, 0x22, 0x5a, 0x0a, 0x05, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x23, 0x0a, 0x04, 0x63, 0x6f,
-	0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e,
-	0x42, 0x6f, 0x61, 0x72, 0x64, 0x2e, 0x43, 0x6f, 0x6c, 0x52, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x1a,
-	0x2c, 0x0a, 0x03, 0x43, 0x6f, 0x6c, 0x12, 0x25, 0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x68, 0x61,
-	0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x32, 0x40, 0x0a,
-	0x0b, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x31, 0x0a, 0x04,
-	0x50, 0x6c, 0x61, 0x79, 0x12, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50,
-	0x6c, 0x61, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42,
-	0x08, 0x5a, 0x06, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x1a, 0xfb, 0x01, 0x0a, 0x0a, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x9a, 0x01, 0x0a, 0x04, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x11,
+	0x0a, 0x0d, 0x4e, 0x4f, 0x54, 0x5f, 0x59, 0x4f, 0x55, 0x52, 0x5f, 0x54, 0x55, 0x52, 0x4e, 0x10,
+	0x01, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x50, 0x4c, 0x41,
+	0x59, 0x45, 0x52, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44,
+	0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4c, 0x4c,
+	0x45, 0x47, 0x41, 0x4c, 0x5f, 0x4d, 0x4f, 0x56, 0x45, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x55,
+	0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x52, 0x4f, 0x4f, 0x4d, 0x10, 0x05, 0x12, 0x11, 0x0a,
+	0x0d, 0x47, 0x41, 0x4d, 0x45, 0x5f, 0x46, 0x49, 0x4e, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x06,
+	0x12, 0x13, 0x0a, 0x0f, 0x55, 0x4e, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41,
+	0x54, 0x45, 0x44, 0x10, 0x07, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x79,
+	0x0a, 0x06, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x62, 0x6c, 0x61, 0x63,
+	0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43,
+	0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x12, 0x21, 0x0a, 0x05, 0x77,
+	0x68, 0x69, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x61, 0x6d,
+	0x65, 0x2e, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05, 0x77, 0x68, 0x69, 0x74, 0x65, 0x12, 0x29,
+	0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72,
+	0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x44, 0x0a, 0x05, 0x43, 0x6c, 0x6f,
+	0x63, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f,
+	0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e,
+	0x69, 0x6e, 0x67, 0x4d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x79, 0x6f, 0x79, 0x6f, 0x6d, 0x69,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x62, 0x79, 0x6f, 0x79, 0x6f, 0x6d, 0x69, 0x22,
+	0x5a, 0x0a, 0x05, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x23, 0x0a, 0x04, 0x63, 0x6f, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x42, 0x6f,
+	0x61, 0x72, 0x64, 0x2e, 0x43, 0x6f, 0x6c, 0x52, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x1a, 0x2c, 0x0a,
+	0x03, 0x43, 0x6f, 0x6c, 0x12, 0x25, 0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x72, 0x61,
+	0x63, 0x74, 0x65, 0x72, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x32, 0x40, 0x0a, 0x0b, 0x47,
+	0x61, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x50, 0x6c,
+	0x61, 0x79, 0x12, 0x11, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x50, 0x6c, 0x61,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x08, 0x5a,
+	0x06, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -733,45 +2245,104 @@ func file_game_proto_rawDescGZIP() []byte {
 	return file_game_proto_rawDescData
 }
 
-var file_game_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_game_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_game_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_game_proto_goTypes = []interface{}{
-	(*PlayRequest)(nil),                // 0: game.PlayRequest
-	(*StartAction)(nil),                // 1: game.StartAction
-	(*MoveAction)(nil),                 // 2: game.MoveAction
-	(*PlayResponse)(nil),               // 3: game.PlayResponse
-	(*Move)(nil),                       // 4: game.Move
-	(*Board)(nil),                      // 5: game.Board
-	(*PlayResponse_WaitingEvent)(nil),  // 6: game.PlayResponse.WaitingEvent
-	(*PlayResponse_ReadyEvent)(nil),    // 7: game.PlayResponse.ReadyEvent
-	(*PlayResponse_MoveEvent)(nil),     // 8: game.PlayResponse.MoveEvent
-	(*PlayResponse_FinishedEvent)(nil), // 9: game.PlayResponse.FinishedEvent
-	(*Board_Col)(nil),                  // 10: game.Board.Col
-	(*Player)(nil),                     // 11: game.Player
-	(Character)(0),                     // 12: game.Character
+	(PlayResponse_FinishedEvent_Termination)(0), // 0: game.PlayResponse.FinishedEvent.Termination
+	(PlayResponse_ErrorEvent_Code)(0),           // 1: game.PlayResponse.ErrorEvent.Code
+	(*PlayRequest)(nil),                         // 2: game.PlayRequest
+	(*Move)(nil),                                // 3: game.Move
+	(*Ply)(nil),                                 // 4: game.Ply
+	(*StartAction)(nil),                         // 5: game.StartAction
+	(*ResumeAction)(nil),                        // 6: game.ResumeAction
+	(*WatchAction)(nil),                         // 7: game.WatchAction
+	(*MoveAction)(nil),                          // 8: game.MoveAction
+	(*TakebackAction)(nil),                      // 9: game.TakebackAction
+	(*TakebackReplyAction)(nil),                 // 10: game.TakebackReplyAction
+	(*ResignAction)(nil),                        // 11: game.ResignAction
+	(*OfferDrawAction)(nil),                     // 12: game.OfferDrawAction
+	(*DrawReplyAction)(nil),                     // 13: game.DrawReplyAction
+	(*AbortAction)(nil),                         // 14: game.AbortAction
+	(*PlayResponse)(nil),                        // 15: game.PlayResponse
+	(*Clocks)(nil),                              // 16: game.Clocks
+	(*Clock)(nil),                               // 17: game.Clock
+	(*Board)(nil),                               // 18: game.Board
+	(*PlayResponse_WaitingEvent)(nil),           // 19: game.PlayResponse.WaitingEvent
+	(*PlayResponse_ReadyEvent)(nil),             // 20: game.PlayResponse.ReadyEvent
+	(*PlayResponse_MoveEvent)(nil),              // 21: game.PlayResponse.MoveEvent
+	(*PlayResponse_ClockEvent)(nil),             // 22: game.PlayResponse.ClockEvent
+	(*PlayResponse_FinishedEvent)(nil),          // 23: game.PlayResponse.FinishedEvent
+	(*PlayResponse_DrawOfferedEvent)(nil),       // 24: game.PlayResponse.DrawOfferedEvent
+	(*PlayResponse_DrawDeclinedEvent)(nil),      // 25: game.PlayResponse.DrawDeclinedEvent
+	(*PlayResponse_PassEvent)(nil),              // 26: game.PlayResponse.PassEvent
+	(*PlayResponse_SnapshotEvent)(nil),          // 27: game.PlayResponse.SnapshotEvent
+	(*PlayResponse_TakebackRequestedEvent)(nil), // 28: game.PlayResponse.TakebackRequestedEvent
+	(*PlayResponse_TakebackEvent)(nil),          // 29: game.PlayResponse.TakebackEvent
+	(*PlayResponse_ErrorEvent)(nil),             // 30: game.PlayResponse.ErrorEvent
+	(*Board_Col)(nil),                           // 31: game.Board.Col
+	(*Player)(nil),                              // 32: game.Player
+	(Character)(0),                              // 33: game.Character
+	(*Reward)(nil),                              // 34: game.Reward
 }
 var file_game_proto_depIdxs = []int32{
-	11, // 0: game.PlayRequest.player:type_name -> game.Player
-	1,  // 1: game.PlayRequest.start:type_name -> game.StartAction
-	2,  // 2: game.PlayRequest.move:type_name -> game.MoveAction
-	4,  // 3: game.MoveAction.move:type_name -> game.Move
-	6,  // 4: game.PlayResponse.waiting:type_name -> game.PlayResponse.WaitingEvent
-	7,  // 5: game.PlayResponse.ready:type_name -> game.PlayResponse.ReadyEvent
-	8,  // 6: game.PlayResponse.move:type_name -> game.PlayResponse.MoveEvent
-	9,  // 7: game.PlayResponse.finished:type_name -> game.PlayResponse.FinishedEvent
-	10, // 8: game.Board.cols:type_name -> game.Board.Col
-	11, // 9: game.PlayResponse.MoveEvent.player:type_name -> game.Player
-	4,  // 10: game.PlayResponse.MoveEvent.move:type_name -> game.Move
-	5,  // 11: game.PlayResponse.MoveEvent.board:type_name -> game.Board
-	12, // 12: game.PlayResponse.FinishedEvent.winner:type_name -> game.Character
-	5,  // 13: game.PlayResponse.FinishedEvent.board:type_name -> game.Board
-	12, // 14: game.Board.Col.cells:type_name -> game.Character
-	0,  // 15: game.GameService.Play:input_type -> game.PlayRequest
-	3,  // 16: game.GameService.Play:output_type -> game.PlayResponse
-	16, // [16:17] is the sub-list for method output_type
-	15, // [15:16] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	32, // 0: game.PlayRequest.player:type_name -> game.Player
+	5,  // 1: game.PlayRequest.start:type_name -> game.StartAction
+	8,  // 2: game.PlayRequest.move:type_name -> game.MoveAction
+	9,  // 3: game.PlayRequest.takeback:type_name -> game.TakebackAction
+	10, // 4: game.PlayRequest.takeback_reply:type_name -> game.TakebackReplyAction
+	6,  // 5: game.PlayRequest.resume:type_name -> game.ResumeAction
+	7,  // 6: game.PlayRequest.watch:type_name -> game.WatchAction
+	11, // 7: game.PlayRequest.resign:type_name -> game.ResignAction
+	12, // 8: game.PlayRequest.offer_draw:type_name -> game.OfferDrawAction
+	13, // 9: game.PlayRequest.draw_reply:type_name -> game.DrawReplyAction
+	14, // 10: game.PlayRequest.abort:type_name -> game.AbortAction
+	33, // 11: game.Ply.character:type_name -> game.Character
+	3,  // 12: game.Ply.move:type_name -> game.Move
+	3,  // 13: game.MoveAction.move:type_name -> game.Move
+	19, // 14: game.PlayResponse.waiting:type_name -> game.PlayResponse.WaitingEvent
+	20, // 15: game.PlayResponse.ready:type_name -> game.PlayResponse.ReadyEvent
+	21, // 16: game.PlayResponse.move:type_name -> game.PlayResponse.MoveEvent
+	23, // 17: game.PlayResponse.finished:type_name -> game.PlayResponse.FinishedEvent
+	30, // 18: game.PlayResponse.error:type_name -> game.PlayResponse.ErrorEvent
+	26, // 19: game.PlayResponse.pass:type_name -> game.PlayResponse.PassEvent
+	28, // 20: game.PlayResponse.takeback_requested:type_name -> game.PlayResponse.TakebackRequestedEvent
+	29, // 21: game.PlayResponse.takeback:type_name -> game.PlayResponse.TakebackEvent
+	27, // 22: game.PlayResponse.snapshot:type_name -> game.PlayResponse.SnapshotEvent
+	22, // 23: game.PlayResponse.clock:type_name -> game.PlayResponse.ClockEvent
+	24, // 24: game.PlayResponse.draw_offered:type_name -> game.PlayResponse.DrawOfferedEvent
+	25, // 25: game.PlayResponse.draw_declined:type_name -> game.PlayResponse.DrawDeclinedEvent
+	17, // 26: game.Clocks.black:type_name -> game.Clock
+	17, // 27: game.Clocks.white:type_name -> game.Clock
+	33, // 28: game.Clocks.running:type_name -> game.Character
+	31, // 29: game.Board.cols:type_name -> game.Board.Col
+	32, // 30: game.PlayResponse.MoveEvent.player:type_name -> game.Player
+	3,  // 31: game.PlayResponse.MoveEvent.move:type_name -> game.Move
+	18, // 32: game.PlayResponse.MoveEvent.board:type_name -> game.Board
+	16, // 33: game.PlayResponse.MoveEvent.clocks:type_name -> game.Clocks
+	16, // 34: game.PlayResponse.ClockEvent.clocks:type_name -> game.Clocks
+	33, // 35: game.PlayResponse.FinishedEvent.winner:type_name -> game.Character
+	18, // 36: game.PlayResponse.FinishedEvent.board:type_name -> game.Board
+	0,  // 37: game.PlayResponse.FinishedEvent.termination:type_name -> game.PlayResponse.FinishedEvent.Termination
+	33, // 38: game.PlayResponse.FinishedEvent.loser:type_name -> game.Character
+	34, // 39: game.PlayResponse.FinishedEvent.reward:type_name -> game.Reward
+	32, // 40: game.PlayResponse.DrawOfferedEvent.player:type_name -> game.Player
+	32, // 41: game.PlayResponse.PassEvent.player:type_name -> game.Player
+	32, // 42: game.PlayResponse.SnapshotEvent.me:type_name -> game.Player
+	18, // 43: game.PlayResponse.SnapshotEvent.board:type_name -> game.Board
+	33, // 44: game.PlayResponse.SnapshotEvent.turn:type_name -> game.Character
+	4,  // 45: game.PlayResponse.SnapshotEvent.moves:type_name -> game.Ply
+	16, // 46: game.PlayResponse.SnapshotEvent.clocks:type_name -> game.Clocks
+	32, // 47: game.PlayResponse.TakebackRequestedEvent.player:type_name -> game.Player
+	18, // 48: game.PlayResponse.TakebackEvent.board:type_name -> game.Board
+	1,  // 49: game.PlayResponse.ErrorEvent.code:type_name -> game.PlayResponse.ErrorEvent.Code
+	33, // 50: game.Board.Col.cells:type_name -> game.Character
+	2,  // 51: game.GameService.Play:input_type -> game.PlayRequest
+	15, // 52: game.GameService.Play:output_type -> game.PlayResponse
+	52, // [52:53] is the sub-list for method output_type
+	51, // [51:52] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_game_proto_init() }
@@ -781,6 +2352,7 @@ func file_game_proto_init() {
 	}
 	file_player_proto_init()
 	file_character_proto_init()
+	file_reward_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_game_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PlayRequest); i {
@@ -795,7 +2367,7 @@ func file_game_proto_init() {
 			}
 		}
 		file_game_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartAction); i {
+			switch v := v.(*Move); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -807,7 +2379,7 @@ func file_game_proto_init() {
 			}
 		}
 		file_game_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MoveAction); i {
+			switch v := v.(*Ply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -819,7 +2391,7 @@ func file_game_proto_init() {
 			}
 		}
 		file_game_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PlayResponse); i {
+			switch v := v.(*StartAction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -831,7 +2403,7 @@ func file_game_proto_init() {
 			}
 		}
 		file_game_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Move); i {
+			switch v := v.(*ResumeAction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -843,7 +2415,7 @@ func file_game_proto_init() {
 			}
 		}
 		file_game_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Board); i {
+			switch v := v.(*WatchAction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -855,7 +2427,7 @@ func file_game_proto_init() {
 			}
 		}
 		file_game_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PlayResponse_WaitingEvent); i {
+			switch v := v.(*MoveAction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -867,7 +2439,7 @@ func file_game_proto_init() {
 			}
 		}
 		file_game_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PlayResponse_ReadyEvent); i {
+			switch v := v.(*TakebackAction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -879,7 +2451,7 @@ func file_game_proto_init() {
 			}
 		}
 		file_game_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PlayResponse_MoveEvent); i {
+			switch v := v.(*TakebackReplyAction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -891,7 +2463,7 @@ func file_game_proto_init() {
 			}
 		}
 		file_game_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PlayResponse_FinishedEvent); i {
+			switch v := v.(*ResignAction); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -903,6 +2475,234 @@ func file_game_proto_init() {
 			}
 		}
 		file_game_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OfferDrawAction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrawReplyAction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AbortAction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Clocks); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Clock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Board); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayResponse_WaitingEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayResponse_ReadyEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayResponse_MoveEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayResponse_ClockEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayResponse_FinishedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayResponse_DrawOfferedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayResponse_DrawDeclinedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayResponse_PassEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayResponse_SnapshotEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayResponse_TakebackRequestedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayResponse_TakebackEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayResponse_ErrorEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_game_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Board_Col); i {
 			case 0:
 				return &v.state
@@ -918,25 +2718,42 @@ func file_game_proto_init() {
 	file_game_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*PlayRequest_Start)(nil),
 		(*PlayRequest_Move)(nil),
+		(*PlayRequest_Takeback)(nil),
+		(*PlayRequest_TakebackReply)(nil),
+		(*PlayRequest_Resume)(nil),
+		(*PlayRequest_Watch)(nil),
+		(*PlayRequest_Resign)(nil),
+		(*PlayRequest_OfferDraw)(nil),
+		(*PlayRequest_DrawReply)(nil),
+		(*PlayRequest_Abort)(nil),
 	}
-	file_game_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_game_proto_msgTypes[13].OneofWrappers = []interface{}{
 		(*PlayResponse_Waiting)(nil),
 		(*PlayResponse_Ready)(nil),
 		(*PlayResponse_Move)(nil),
 		(*PlayResponse_Finished)(nil),
+		(*PlayResponse_Error)(nil),
+		(*PlayResponse_Pass)(nil),
+		(*PlayResponse_TakebackRequested)(nil),
+		(*PlayResponse_Takeback)(nil),
+		(*PlayResponse_Snapshot)(nil),
+		(*PlayResponse_Clock)(nil),
+		(*PlayResponse_DrawOffered)(nil),
+		(*PlayResponse_DrawDeclined)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_game_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      2,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_game_proto_goTypes,
 		DependencyIndexes: file_game_proto_depIdxs,
+		EnumInfos:         file_game_proto_enumTypes,
 		MessageInfos:      file_game_proto_msgTypes,
 	}.Build()
 	File_game_proto = out.File
@@ -19,7 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	MatchingService_JoinRoom_FullMethodName = "/game.MatchingService/JoinRoom"
+	MatchingService_JoinRoom_FullMethodName       = "/game.MatchingService/JoinRoom"
+	MatchingService_CreateRoom_FullMethodName     = "/game.MatchingService/CreateRoom"
+	MatchingService_JoinRoomByCode_FullMethodName = "/game.MatchingService/JoinRoomByCode"
+	MatchingService_ListRooms_FullMethodName      = "/game.MatchingService/ListRooms"
 )
 
 // MatchingServiceClient is the client API for MatchingService service.
@@ -27,6 +30,12 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type MatchingServiceClient interface {
 	JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ...grpc.CallOption) (MatchingService_JoinRoomClient, error)
+	// 招待コード付きの部屋を作成し、ゲストが参加するまで待つ。最初のレスポンスで招待コードを返す
+	CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (MatchingService_CreateRoomClient, error)
+	// 招待コードの部屋にゲストとして参加する
+	JoinRoomByCode(ctx context.Context, in *JoinRoomByCodeRequest, opts ...grpc.CallOption) (*JoinRoomResponse, error)
+	// ゲストを待っている公開の部屋の一覧
+	ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error)
 }
 
 type matchingServiceClient struct {
@@ -69,11 +78,67 @@ func (x *matchingServiceJoinRoomClient) Recv() (*JoinRoomResponse, error) {
 	return m, nil
 }
 
+func (c *matchingServiceClient) CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (MatchingService_CreateRoomClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MatchingService_ServiceDesc.Streams[1], MatchingService_CreateRoom_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &matchingServiceCreateRoomClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MatchingService_CreateRoomClient interface {
+	Recv() (*JoinRoomResponse, error)
+	grpc.ClientStream
+}
+
+type matchingServiceCreateRoomClient struct {
+	grpc.ClientStream
+}
+
+func (x *matchingServiceCreateRoomClient) Recv() (*JoinRoomResponse, error) {
+	m := new(JoinRoomResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *matchingServiceClient) JoinRoomByCode(ctx context.Context, in *JoinRoomByCodeRequest, opts ...grpc.CallOption) (*JoinRoomResponse, error) {
+	out := new(JoinRoomResponse)
+	err := c.cc.Invoke(ctx, MatchingService_JoinRoomByCode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingServiceClient) ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error) {
+	out := new(ListRoomsResponse)
+	err := c.cc.Invoke(ctx, MatchingService_ListRooms_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MatchingServiceServer is the server API for MatchingService service.
 // All implementations must embed UnimplementedMatchingServiceServer
 // for forward compatibility
 type MatchingServiceServer interface {
 	JoinRoom(*JoinRoomRequest, MatchingService_JoinRoomServer) error
+	// 招待コード付きの部屋を作成し、ゲストが参加するまで待つ。最初のレスポンスで招待コードを返す
+	CreateRoom(*CreateRoomRequest, MatchingService_CreateRoomServer) error
+	// 招待コードの部屋にゲストとして参加する
+	JoinRoomByCode(context.Context, *JoinRoomByCodeRequest) (*JoinRoomResponse, error)
+	// ゲストを待っている公開の部屋の一覧
+	ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error)
 	mustEmbedUnimplementedMatchingServiceServer()
 }
 
@@ -84,6 +149,15 @@ type UnimplementedMatchingServiceServer struct {
 func (UnimplementedMatchingServiceServer) JoinRoom(*JoinRoomRequest, MatchingService_JoinRoomServer) error {
 	return status.Errorf(codes.Unimplemented, "method JoinRoom not implemented")
 }
+func (UnimplementedMatchingServiceServer) CreateRoom(*CreateRoomRequest, MatchingService_CreateRoomServer) error {
+	return status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
+}
+func (UnimplementedMatchingServiceServer) JoinRoomByCode(context.Context, *JoinRoomByCodeRequest) (*JoinRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinRoomByCode not implemented")
+}
+func (UnimplementedMatchingServiceServer) ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRooms not implemented")
+}
 func (UnimplementedMatchingServiceServer) mustEmbedUnimplementedMatchingServiceServer() {}
 
 // UnsafeMatchingServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -118,19 +192,90 @@ func (x *matchingServiceJoinRoomServer) Send(m *JoinRoomResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _MatchingService_CreateRoom_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CreateRoomRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchingServiceServer).CreateRoom(m, &matchingServiceCreateRoomServer{stream})
+}
+
+type MatchingService_CreateRoomServer interface {
+	Send(*JoinRoomResponse) error
+	grpc.ServerStream
+}
+
+type matchingServiceCreateRoomServer struct {
+	grpc.ServerStream
+}
+
+func (x *matchingServiceCreateRoomServer) Send(m *JoinRoomResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MatchingService_JoinRoomByCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRoomByCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingServiceServer).JoinRoomByCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingService_JoinRoomByCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingServiceServer).JoinRoomByCode(ctx, req.(*JoinRoomByCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingService_ListRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoomsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingServiceServer).ListRooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingService_ListRooms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingServiceServer).ListRooms(ctx, req.(*ListRoomsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MatchingService_ServiceDesc is the grpc.ServiceDesc for MatchingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var MatchingService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "game.MatchingService",
 	HandlerType: (*MatchingServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "JoinRoomByCode",
+			Handler:    _MatchingService_JoinRoomByCode_Handler,
+		},
+		{
+			MethodName: "ListRooms",
+			Handler:    _MatchingService_ListRooms_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "JoinRoom",
 			Handler:       _MatchingService_JoinRoom_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "CreateRoom",
+			Handler:       _MatchingService_CreateRoom_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "matching.proto",
 }
@@ -0,0 +1,267 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.2
+// source: reward.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	RewardService_GetInventory_FullMethodName      = "/game.RewardService/GetInventory"
+	RewardService_ListRewardHistory_FullMethodName = "/game.RewardService/ListRewardHistory"
+	RewardService_GetOdds_FullMethodName           = "/game.RewardService/GetOdds"
+	RewardService_GetSeed_FullMethodName           = "/game.RewardService/GetSeed"
+	RewardService_RotateSeed_FullMethodName        = "/game.RewardService/RotateSeed"
+)
+
+// RewardServiceClient is the client API for RewardService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RewardServiceClient interface {
+	// プレイヤーが持っているカードと枚数
+	GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*GetInventoryResponse, error)
+	// プレイヤーに付与した報酬の履歴。明かした種で抽選をやり直して確かめられる
+	ListRewardHistory(ctx context.Context, in *ListRewardHistoryRequest, opts ...grpc.CallOption) (*ListRewardHistoryResponse, error)
+	// 公開している提供割合
+	GetOdds(ctx context.Context, in *GetOddsRequest, opts ...grpc.CallOption) (*GetOddsResponse, error)
+	// プレイヤーの次の抽選に使う種。サーバーの種はハッシュだけを返す
+	GetSeed(ctx context.Context, in *GetSeedRequest, opts ...grpc.CallOption) (*GetSeedResponse, error)
+	// 今の種を明かし、新しい種に入れ替える。マッチング時に発行したトークンが必要
+	RotateSeed(ctx context.Context, in *RotateSeedRequest, opts ...grpc.CallOption) (*RotateSeedResponse, error)
+}
+
+type rewardServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRewardServiceClient(cc grpc.ClientConnInterface) RewardServiceClient {
+	return &rewardServiceClient{cc}
+}
+
+func (c *rewardServiceClient) GetInventory(ctx context.Context, in *GetInventoryRequest, opts ...grpc.CallOption) (*GetInventoryResponse, error) {
+	out := new(GetInventoryResponse)
+	err := c.cc.Invoke(ctx, RewardService_GetInventory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) ListRewardHistory(ctx context.Context, in *ListRewardHistoryRequest, opts ...grpc.CallOption) (*ListRewardHistoryResponse, error) {
+	out := new(ListRewardHistoryResponse)
+	err := c.cc.Invoke(ctx, RewardService_ListRewardHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) GetOdds(ctx context.Context, in *GetOddsRequest, opts ...grpc.CallOption) (*GetOddsResponse, error) {
+	out := new(GetOddsResponse)
+	err := c.cc.Invoke(ctx, RewardService_GetOdds_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) GetSeed(ctx context.Context, in *GetSeedRequest, opts ...grpc.CallOption) (*GetSeedResponse, error) {
+	out := new(GetSeedResponse)
+	err := c.cc.Invoke(ctx, RewardService_GetSeed_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rewardServiceClient) RotateSeed(ctx context.Context, in *RotateSeedRequest, opts ...grpc.CallOption) (*RotateSeedResponse, error) {
+	out := new(RotateSeedResponse)
+	err := c.cc.Invoke(ctx, RewardService_RotateSeed_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RewardServiceServer is the server API for RewardService service.
+// All implementations must embed UnimplementedRewardServiceServer
+// for forward compatibility
+type RewardServiceServer interface {
+	// プレイヤーが持っているカードと枚数
+	GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error)
+	// プレイヤーに付与した報酬の履歴。明かした種で抽選をやり直して確かめられる
+	ListRewardHistory(context.Context, *ListRewardHistoryRequest) (*ListRewardHistoryResponse, error)
+	// 公開している提供割合
+	GetOdds(context.Context, *GetOddsRequest) (*GetOddsResponse, error)
+	// プレイヤーの次の抽選に使う種。サーバーの種はハッシュだけを返す
+	GetSeed(context.Context, *GetSeedRequest) (*GetSeedResponse, error)
+	// 今の種を明かし、新しい種に入れ替える。マッチング時に発行したトークンが必要
+	RotateSeed(context.Context, *RotateSeedRequest) (*RotateSeedResponse, error)
+	mustEmbedUnimplementedRewardServiceServer()
+}
+
+// UnimplementedRewardServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRewardServiceServer struct {
+}
+
+func (UnimplementedRewardServiceServer) GetInventory(context.Context, *GetInventoryRequest) (*GetInventoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInventory not implemented")
+}
+func (UnimplementedRewardServiceServer) ListRewardHistory(context.Context, *ListRewardHistoryRequest) (*ListRewardHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRewardHistory not implemented")
+}
+func (UnimplementedRewardServiceServer) GetOdds(context.Context, *GetOddsRequest) (*GetOddsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOdds not implemented")
+}
+func (UnimplementedRewardServiceServer) GetSeed(context.Context, *GetSeedRequest) (*GetSeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeed not implemented")
+}
+func (UnimplementedRewardServiceServer) RotateSeed(context.Context, *RotateSeedRequest) (*RotateSeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateSeed not implemented")
+}
+func (UnimplementedRewardServiceServer) mustEmbedUnimplementedRewardServiceServer() {}
+
+// UnsafeRewardServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RewardServiceServer will
+// result in compilation errors.
+type UnsafeRewardServiceServer interface {
+	mustEmbedUnimplementedRewardServiceServer()
+}
+
+func RegisterRewardServiceServer(s grpc.ServiceRegistrar, srv RewardServiceServer) {
+	s.RegisterService(&RewardService_ServiceDesc, srv)
+}
+
+func _RewardService_GetInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).GetInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_GetInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).GetInventory(ctx, req.(*GetInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_ListRewardHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRewardHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).ListRewardHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_ListRewardHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).ListRewardHistory(ctx, req.(*ListRewardHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_GetOdds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOddsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).GetOdds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_GetOdds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).GetOdds(ctx, req.(*GetOddsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_GetSeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).GetSeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_GetSeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).GetSeed(ctx, req.(*GetSeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RewardService_RotateSeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateSeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RewardServiceServer).RotateSeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RewardService_RotateSeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RewardServiceServer).RotateSeed(ctx, req.(*RotateSeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RewardService_ServiceDesc is the grpc.ServiceDesc for RewardService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RewardService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "game.RewardService",
+	HandlerType: (*RewardServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInventory",
+			Handler:    _RewardService_GetInventory_Handler,
+		},
+		{
+			MethodName: "ListRewardHistory",
+			Handler:    _RewardService_ListRewardHistory_Handler,
+		},
+		{
+			MethodName: "GetOdds",
+			Handler:    _RewardService_GetOdds_Handler,
+		},
+		{
+			MethodName: "GetSeed",
+			Handler:    _RewardService_GetSeed_Handler,
+		},
+		{
+			MethodName: "RotateSeed",
+			Handler:    _RewardService_RotateSeed_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "reward.proto",
+}
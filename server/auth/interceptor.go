@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authorizationKey トークンを送るmetadataのキー。値は"Bearer <トークン>"
+const authorizationKey = "authorization"
+
+// Verifier トークンを確かめる。署名の他に、取り消されていないかも確かめる
+type Verifier interface {
+	Verify(token string) (*Identity, error)
+}
+
+type identityKey struct{}
+
+// FromContext interceptorで確かめたプレイヤー。トークンが送られていなければfalse
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok
+}
+
+// UnaryServerInterceptor トークンが送られていれば確かめ、プレイヤーをcontextに入れる
+func UnaryServerInterceptor(v Verifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor トークンが送られていれば確かめ、プレイヤーをstreamのcontextに入れる
+func StreamServerInterceptor(v Verifier) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), v)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &stream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate metadataのトークンを確かめる。トークンがなければそのまま返し、使えるかどうかはハンドラで決める。
+// 観戦やマッチングのように、トークンなしで使えるRPCもある
+func authenticate(ctx context.Context, v Verifier) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	values := md.Get(authorizationKey)
+	if len(values) == 0 {
+		return ctx, nil
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid authorization header")
+	}
+	id, err := v.Verify(token)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	return context.WithValue(ctx, identityKey{}, id), nil
+}
+
+// stream contextを差し替えたServerStream
+type stream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *stream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,180 @@
+// Package auth マッチング時に発行する署名付きトークンと、トークンからプレイヤーを確かめるgRPCのinterceptor
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+var (
+	// ErrInvalidToken 形式が正しくないか、署名が一致しないトークン
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrExpiredToken 有効期限が過ぎたトークン
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+const (
+	// keySize HMAC-SHA256の鍵の長さ
+	keySize = 32
+	// TokenTTL トークンの有効期限。長い対局の途中でサーバーを再起動しても再接続できるよう、長めにする
+	TokenTTL = 24 * time.Hour
+	// PlayerTokenTTL プレイヤートークンの有効期限。マッチングのたびに発行し直すので、続けて遊んでいれば切れない
+	PlayerTokenTTL = 90 * 24 * time.Hour
+)
+
+// Identity トークンで確かめたプレイヤーと、着席する部屋。プレイヤートークンでは部屋は空
+type Identity struct {
+	RoomID    string         `json:"room"`
+	PlayerID  string         `json:"player"`
+	Name      string         `json:"name"`
+	Character game.Character `json:"color"`
+	ExpiresAt int64          `json:"exp"` // Unix時間(秒)
+}
+
+// Player トークンのプレイヤー
+func (id *Identity) Player() *game.Player {
+	return &game.Player{
+		ID:        id.PlayerID,
+		Character: id.Character,
+		Name:      id.Name,
+	}
+}
+
+// Signer HMAC-SHA256でトークンに署名し、検証する
+type Signer struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+func NewSigner(key []byte) *Signer {
+	return &Signer{
+		key: key,
+		ttl: TokenTTL,
+		now: time.Now,
+	}
+}
+
+// Sign 部屋とプレイヤーのトークンを作る。形式は"<JSONのbase64>.<署名のbase64>"
+func (s *Signer) Sign(roomID string, p *game.Player) (string, error) {
+	return s.sign(&Identity{
+		RoomID:    roomID,
+		PlayerID:  p.ID,
+		Name:      p.Name,
+		Character: p.Character,
+		ExpiresAt: s.now().Add(s.ttl).Unix(),
+	})
+}
+
+// SignPlayer プレイヤーのプロフィールに結びつけるトークンを作る。同じ名前で参加し直す時に送り、本人であることを示す。
+// 部屋を含まないので、GameServiceでは使えない
+func (s *Signer) SignPlayer(playerID string) (string, error) {
+	return s.sign(&Identity{
+		PlayerID:  playerID,
+		ExpiresAt: s.now().Add(PlayerTokenTTL).Unix(),
+	})
+}
+
+// VerifyPlayer プレイヤートークンの署名と有効期限を確かめ、プレイヤーIDを返す。部屋のトークンは使えない
+func (s *Signer) VerifyPlayer(token string) (string, error) {
+	id, err := s.Verify(token)
+	if err != nil {
+		return "", err
+	}
+	if id.RoomID != "" {
+		return "", ErrInvalidToken
+	}
+	return id.PlayerID, nil
+}
+
+func (s *Signer) sign(id *Identity) (string, error) {
+	payload, err := json.Marshal(id)
+	if err != nil {
+		return "", err
+	}
+	body := base64.RawURLEncoding.EncodeToString(payload)
+	return body + "." + base64.RawURLEncoding.EncodeToString(s.mac(body)), nil
+}
+
+// Verify 署名と有効期限を確かめ、トークンのプレイヤーを返す
+func (s *Signer) Verify(token string) (*Identity, error) {
+	body, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, s.mac(body)) {
+		return nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	id := &Identity{}
+	if err := json.Unmarshal(payload, id); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if s.now().Unix() >= id.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+	return id, nil
+}
+
+func (s *Signer) mac(body string) []byte {
+	m := hmac.New(sha256.New, s.key)
+	m.Write([]byte(body))
+	return m.Sum(nil)
+}
+
+// GenerateKey 署名の鍵を乱数で作る
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// LoadKey pathのファイルから署名の鍵を読み込む。ファイルがなければ作成して書き込む。
+// 再起動しても同じ鍵を使うので、発行済みのトークンで再接続できる。pathが空の場合は保存せずに作る
+func LoadKey(path string) ([]byte, error) {
+	if path == "" {
+		return GenerateKey()
+	}
+	b, err := os.ReadFile(path)
+	if err == nil {
+		key, err := hex.DecodeString(strings.TrimSpace(string(b)))
+		if err != nil || len(key) < keySize {
+			return nil, errors.New("invalid key file: " + path)
+		}
+		return key, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	key, err := GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	// 他のユーザーに読まれるとトークンを偽造できるので、自分だけが読めるようにする
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0o600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+// newTestSigner 時刻をnowで固定した署名器
+func newTestSigner(key []byte, now time.Time) *Signer {
+	s := NewSigner(key)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestSignVerify(t *testing.T) {
+	now := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestSigner(bytes.Repeat([]byte{1}, keySize), now)
+	p := &game.Player{ID: "player", Name: "alice", Character: game.White}
+	token, err := s.Sign("room", p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := s.Verify(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Identity{RoomID: "room", PlayerID: "player", Name: "alice", Character: game.White, ExpiresAt: now.Add(TokenTTL).Unix()}
+	if *id != want {
+		t.Errorf("Verify = %+v, want %+v", *id, want)
+	}
+	if got := id.Player(); *got != *p {
+		t.Errorf("Player = %+v, want %+v", got, p)
+	}
+}
+
+func TestVerifyRejectsTampered(t *testing.T) {
+	s := newTestSigner(bytes.Repeat([]byte{1}, keySize), time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC))
+	token, err := s.Sign("room", &game.Player{ID: "player", Name: "alice", Character: game.Black})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, sig, _ := strings.Cut(token, ".")
+
+	// 別のプレイヤーになりすましたペイロードに、元の署名を付ける
+	forged, err := s.Sign("room", &game.Player{ID: "mallory", Name: "alice", Character: game.Black})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forgedBody, _, _ := strings.Cut(forged, ".")
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mac[0] ^= 1
+
+	tokens := map[string]string{
+		"payload":      forgedBody + "." + sig,
+		"signature":    body + "." + base64.RawURLEncoding.EncodeToString(mac),
+		"no signature": body,
+		"not base64":   body + ".!!!",
+		"empty":        "",
+	}
+	for name, token := range tokens {
+		if _, err := s.Verify(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%v: Verify = %v, want ErrInvalidToken", name, err)
+		}
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	issued := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestSigner(bytes.Repeat([]byte{1}, keySize), issued)
+	token, err := s.Sign("room", &game.Player{ID: "player", Character: game.Black})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		at   time.Time
+		want error
+	}{
+		{issued.Add(TokenTTL - time.Second), nil},
+		// 有効期限の時刻ちょうどで切れる
+		{issued.Add(TokenTTL), ErrExpiredToken},
+		{issued.Add(TokenTTL + time.Hour), ErrExpiredToken},
+	}
+	for _, tt := range tests {
+		s.now = func() time.Time { return tt.at }
+		if _, err := s.Verify(token); !errors.Is(err, tt.want) {
+			t.Errorf("Verify at %v = %v, want %v", tt.at, err, tt.want)
+		}
+	}
+}
+
+func TestVerifyWrongKey(t *testing.T) {
+	now := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestSigner(bytes.Repeat([]byte{1}, keySize), now)
+	other := newTestSigner(bytes.Repeat([]byte{2}, keySize), now)
+	token, err := other.Sign("room", &game.Player{ID: "player", Character: game.Black})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Verify(token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify of a token signed with another key = %v, want ErrInvalidToken", err)
+	}
+	playerToken, err := other.SignPlayer("player")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.VerifyPlayer(playerToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("VerifyPlayer of a token signed with another key = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestVerifyPlayer(t *testing.T) {
+	issued := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestSigner(bytes.Repeat([]byte{1}, keySize), issued)
+	token, err := s.SignPlayer("player")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, err := s.VerifyPlayer(token); err != nil || id != "player" {
+		t.Errorf("VerifyPlayer = %q, %v, want %q", id, err, "player")
+	}
+
+	// 部屋のトークンはプレイヤートークンとして使えない
+	roomToken, err := s.Sign("room", &game.Player{ID: "player", Character: game.Black})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.VerifyPlayer(roomToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("VerifyPlayer of a room token = %v, want ErrInvalidToken", err)
+	}
+
+	s.now = func() time.Time { return issued.Add(PlayerTokenTTL) }
+	if _, err := s.VerifyPlayer(token); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("VerifyPlayer after %v = %v, want ErrExpiredToken", PlayerTokenTTL, err)
+	}
+}
@@ -0,0 +1,236 @@
+//go:build unix
+
+// マッチングで待っているプレイヤーが多い場合の、相手が見つかってから通知されるまでの時間と、待っている間のCPU使用時間を測る。
+// 以前のJoinRoomと同じく1秒おきに確認する方式を並べて測り、比較できるようにする
+//
+//	go run ./server/bench -waiters 1000 -idle 5s
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"kazuki.matsumoto/reversi/gen/pb"
+	"kazuki.matsumoto/reversi/server/auth"
+	"kazuki.matsumoto/reversi/server/handler"
+	"kazuki.matsumoto/reversi/server/reward"
+	"kazuki.matsumoto/reversi/server/storage"
+)
+
+func main() {
+	waiters := flag.Int("waiters", 1000, "同時に待つプレイヤーの数")
+	idle := flag.Duration("idle", 5*time.Second, "全員が待っている状態でCPU使用時間を測る時間")
+	flag.Parse()
+
+	// ハンドラが出力するマッチングのログで結果が埋もれないよう、標準出力を捨てる
+	out := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Stdout = devNull
+
+	results := []*result{
+		benchQueue(*waiters, *idle),
+		benchInvite(*waiters, *idle),
+		benchPolling(*waiters, *idle),
+	}
+
+	fmt.Fprintf(out, "waiters=%v idle=%v\n", *waiters, *idle)
+	fmt.Fprintf(out, "%-10v %12v %12v %12v %14v\n", "scenario", "p50", "p99", "max", "cpu/idle-sec")
+	for _, r := range results {
+		r.print(out)
+	}
+}
+
+// result 1つの方式の計測結果
+type result struct {
+	name      string
+	latencies []time.Duration // 相手が見つかってから、待っていた側に通知されるまでの時間
+	idleCPU   time.Duration   // 全員が待っている間に使ったCPU時間
+	idle      time.Duration
+}
+
+func (r *result) print(out *os.File) {
+	sort.Slice(r.latencies, func(i, j int) bool {
+		return r.latencies[i] < r.latencies[j]
+	})
+	p := func(q float64) time.Duration {
+		return r.latencies[int(q*float64(len(r.latencies)-1))]
+	}
+	fmt.Fprintf(out, "%-10v %12v %12v %12v %14v\n",
+		r.name, p(0.5), p(0.99), p(1), time.Duration(float64(r.idleCPU)/r.idle.Seconds()))
+}
+
+// benchQueue JoinRoomで、白を希望するプレイヤーを待たせておき、黒を希望するプレイヤーを並ばせてマッチングさせる
+func benchQueue(n int, idle time.Duration) *result {
+	m := newMatchingHandler()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 待っているプレイヤーを名前で引けるようにしておく
+	hosts := make(map[string]*stream, n)
+	for i := 0; i < n; i++ {
+		host := newStream(ctx)
+		name := fmt.Sprintf("host-%d", i)
+		go m.JoinRoom(&pb.JoinRoomRequest{Color: pb.Character_WHITE, Name: name}, host)
+		hosts[name] = host
+		host.wait(pb.JoinRoomResponse_WAITING)
+	}
+	cpu := measureCPU(idle)
+
+	res := &result{name: "queue", idleCPU: cpu, idle: idle}
+	for range hosts {
+		guest := newStream(ctx)
+		start := time.Now()
+		go m.JoinRoom(&pb.JoinRoomRequest{Color: pb.Character_BLACK}, guest)
+		// 黒を希望する一人目と、白を希望して最も長く待っているプレイヤーがマッチングする
+		room := guest.wait(pb.JoinRoomResponse_MATCHED).GetRoom()
+		host := hosts[room.GetHost().GetName()]
+		host.wait(pb.JoinRoomResponse_MATCHED)
+		res.latencies = append(res.latencies, host.at.Sub(start))
+	}
+	return res
+}
+
+// benchInvite CreateRoomで部屋を作成したホストを待たせておき、招待コードでゲストを参加させる
+func benchInvite(n int, idle time.Duration) *result {
+	m := newMatchingHandler()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hosts := make([]*stream, n)
+	codes := make([]string, n)
+	for i := range hosts {
+		hosts[i] = newStream(ctx)
+		go m.CreateRoom(&pb.CreateRoomRequest{}, hosts[i])
+		codes[i] = hosts[i].wait(pb.JoinRoomResponse_WAITING).GetRoom().GetInviteCode()
+	}
+	cpu := measureCPU(idle)
+
+	res := &result{name: "invite", idleCPU: cpu, idle: idle}
+	for i, host := range hosts {
+		start := time.Now()
+		if _, err := m.JoinRoomByCode(ctx, &pb.JoinRoomByCodeRequest{InviteCode: codes[i]}); err != nil {
+			panic(err)
+		}
+		host.wait(pb.JoinRoomResponse_MATCHED)
+		res.latencies = append(res.latencies, host.at.Sub(start))
+	}
+	return res
+}
+
+// benchPolling 以前のJoinRoomと同じく、待っている側が1秒おきにロックを取ってゲストが参加したかを確認する方式
+func benchPolling(n int, idle time.Duration) *result {
+	var mu sync.RWMutex
+	joined := make([]time.Time, n)
+	notified := make([]chan time.Time, n)
+	for i := range notified {
+		notified[i] = make(chan time.Time, 1)
+		go func(i int) {
+			for {
+				mu.RLock()
+				j := joined[i]
+				mu.RUnlock()
+				if !j.IsZero() {
+					notified[i] <- time.Now()
+					return
+				}
+				time.Sleep(1 * time.Second)
+			}
+		}(i)
+	}
+	cpu := measureCPU(idle)
+
+	// 確認する周期のどの時点で参加しても良いよう、1秒の間に均等にばらして参加させる
+	res := &result{name: "polling", idleCPU: cpu, idle: idle}
+	for i := range joined {
+		mu.Lock()
+		joined[i] = time.Now()
+		mu.Unlock()
+		time.Sleep(time.Second / time.Duration(n))
+	}
+	for i := range notified {
+		res.latencies = append(res.latencies, (<-notified[i]).Sub(joined[i]))
+	}
+	return res
+}
+
+// measureCPU d待つ間に、このプロセスが使ったCPU時間
+func measureCPU(d time.Duration) time.Duration {
+	before := cpuTime()
+	time.Sleep(d)
+	return cpuTime() - before
+}
+
+func cpuTime() time.Duration {
+	var u syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &u); err != nil {
+		panic(err)
+	}
+	return time.Duration(u.Utime.Nano() + u.Stime.Nano())
+}
+
+func newMatchingHandler() *handler.MatchingHandler {
+	store := storage.NewMemoryStore()
+	registry := handler.NewRegistry()
+	key, err := auth.GenerateKey()
+	if err != nil {
+		panic(err)
+	}
+	signer := auth.NewSigner(key)
+	sessions := handler.NewSessionStore(store, signer)
+	profiles := handler.NewProfiles(storage.NewMemoryProfileStore(), registry, signer)
+	rewards := handler.NewRewards(storage.NewMemoryRewardStore(), storage.NewMemorySeedStore(), reward.Default(), profiles)
+	games := handler.NewGameHandler(registry, store, profiles, rewards)
+	return handler.NewMatchingHandler(games, registry, sessions, store, profiles, rewards)
+}
+
+// stream マッチングのレスポンスを受け取るstream。JoinRoomとCreateRoomで共通
+type stream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan received
+	at   time.Time // waitで最後に受け取ったレスポンスの時刻
+}
+
+// received 受け取ったレスポンスと、その時刻
+type received struct {
+	res *pb.JoinRoomResponse
+	at  time.Time
+}
+
+func newStream(ctx context.Context) *stream {
+	return &stream{
+		ctx:  ctx,
+		sent: make(chan received, 2),
+	}
+}
+
+func (s *stream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *stream) Send(res *pb.JoinRoomResponse) error {
+	s.sent <- received{res: res, at: time.Now()}
+	return nil
+}
+
+// wait statusのレスポンスを受け取るまで待つ
+func (s *stream) wait(status pb.JoinRoomResponse_Status) *pb.JoinRoomResponse {
+	for r := range s.sent {
+		if r.res.GetStatus() == status {
+			s.at = r.at
+			return r.res
+		}
+	}
+	return nil
+}
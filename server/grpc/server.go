@@ -1,39 +1,166 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 	"kazuki.matsumoto/reversi/gen/pb"
+	"kazuki.matsumoto/reversi/server/auth"
 	"kazuki.matsumoto/reversi/server/handler"
+	"kazuki.matsumoto/reversi/server/reward"
+	"kazuki.matsumoto/reversi/server/storage"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"syscall"
+	"time"
 )
 
 func main() {
+	data := flag.String("data", "data/reversi.jsonl", "対局の記録を保存するファイル。空の場合は保存しない")
+	profileData := flag.String("profiles", "data/profiles.jsonl", "プレイヤーのプロフィールを保存するファイル。空の場合は保存しない")
+	rewardData := flag.String("rewards", "data/rewards.jsonl", "対局に勝ったプレイヤーに付与した報酬を保存するファイル。空の場合は保存しない")
+	seedData := flag.String("seeds", "data/seeds.jsonl", "報酬の抽選に使う種を保存するファイル。空の場合は保存しない")
+	rewardTable := flag.String("rewardtable", "", "報酬の抽選表のJSONのファイル。SIGHUPで読み込み直す。空の場合は組み込みの抽選表を使う")
+	keyFile := flag.String("key", "data/auth.key", "トークンに署名する鍵のファイル。なければ作成する。空の場合は起動ごとに作る")
+	flag.Parse()
+
 	port := 50052
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	server := grpc.NewServer()
+	store, err := openStore(*data)
+	if err != nil {
+		log.Fatalf("failed to open store: %v", err)
+	}
+	defer store.Close()
+	profileStore, err := openProfileStore(*profileData)
+	if err != nil {
+		log.Fatalf("failed to open profile store: %v", err)
+	}
+	defer profileStore.Close()
+	rewardStore, err := openRewardStore(*rewardData)
+	if err != nil {
+		log.Fatalf("failed to open reward store: %v", err)
+	}
+	defer rewardStore.Close()
+	seedStore, err := openSeedStore(*seedData)
+	if err != nil {
+		log.Fatalf("failed to open seed store: %v", err)
+	}
+	defer seedStore.Close()
+	table, err := loadRewardTable(*rewardTable)
+	if err != nil {
+		log.Fatalf("failed to load reward table: %v", err)
+	}
+	key, err := auth.LoadKey(*keyFile)
+	if err != nil {
+		log.Fatalf("failed to load key: %v", err)
+	}
+
+	// 部屋とプレイヤーのIDは、両方のサービスで共有するRegistryで発行する
+	registry := handler.NewRegistry()
+	signer := auth.NewSigner(key)
+	sessions := handler.NewSessionStore(store, signer)
+	profiles := handler.NewProfiles(profileStore, registry, signer)
+	rewards := handler.NewRewards(rewardStore, seedStore, table, profiles)
+	gameHandler := handler.NewGameHandler(registry, store, profiles, rewards)
+	matchingHandler := handler.NewMatchingHandler(gameHandler, registry, sessions, store, profiles, rewards)
+	// 前回終了時に対局中だったゲームを再開する
+	if err := handler.Restore(store, registry, gameHandler, sessions); err != nil {
+		log.Fatalf("failed to restore games: %v", err)
+	}
 
-	pb.RegisterMatchingServiceServer(server, handler.NewMatchingHandler())
-	pb.RegisterGameServiceServer(server, handler.NewGameHandler())
+	server := grpc.NewServer(
+		// 応答しなくなったクライアントのstreamを閉じ、切断として扱えるようにする
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    30 * time.Second,
+			Timeout: 10 * time.Second,
+		}),
+		// マッチング時に発行したトークンを確かめ、GameServiceではトークンからプレイヤーを決める
+		grpc.UnaryInterceptor(auth.UnaryServerInterceptor(sessions)),
+		grpc.StreamInterceptor(auth.StreamServerInterceptor(sessions)),
+	)
+	pb.RegisterMatchingServiceServer(server, matchingHandler)
+	pb.RegisterGameServiceServer(server, gameHandler)
+	pb.RegisterLeaderboardServiceServer(server, handler.NewLeaderboardHandler(profiles))
+	pb.RegisterRewardServiceServer(server, handler.NewRewardHandler(rewards, profiles))
 
 	reflection.Register(server)
 
+	// 終了した対局や始まらない対局を片付ける
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go handler.NewLifecycle(registry, gameHandler, sessions).Run(ctx)
+	// 抽選表を変更したら、サーバーを止めずにSIGHUPで読み込み直す
+	if *rewardTable != "" {
+		go reloadRewardTable(*rewardTable, rewards)
+	}
+
 	go func() {
 		log.Printf("start gRPC server port: %v", port)
 		server.Serve(lis)
 	}()
 
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 	<-quit
 	log.Println("stopping gRPC server...")
 	server.GracefulStop()
 }
+
+func openStore(path string) (storage.Store, error) {
+	if path == "" {
+		return storage.NewMemoryStore(), nil
+	}
+	return storage.OpenFileStore(path)
+}
+
+func openProfileStore(path string) (storage.ProfileStore, error) {
+	if path == "" {
+		return storage.NewMemoryProfileStore(), nil
+	}
+	return storage.OpenFileProfileStore(path)
+}
+
+func openRewardStore(path string) (storage.RewardStore, error) {
+	if path == "" {
+		return storage.NewMemoryRewardStore(), nil
+	}
+	return storage.OpenFileRewardStore(path)
+}
+
+func openSeedStore(path string) (storage.SeedStore, error) {
+	if path == "" {
+		return storage.NewMemorySeedStore(), nil
+	}
+	return storage.OpenFileSeedStore(path)
+}
+
+func loadRewardTable(path string) (*reward.Table, error) {
+	if path == "" {
+		return reward.Default(), nil
+	}
+	return reward.Load(path)
+}
+
+// reloadRewardTable SIGHUPを受け取るたびに抽選表を読み込み直す。内容がおかしければ元の抽選表を使い続ける
+func reloadRewardTable(path string, rewards *handler.Rewards) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for range hup {
+		table, err := reward.Load(path)
+		if err != nil {
+			log.Printf("failed to reload reward table: %v", err)
+			continue
+		}
+		rewards.SetTable(table)
+		log.Printf("reloaded reward table path=%v", path)
+	}
+}
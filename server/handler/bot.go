@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"log"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+// SeatBot 部屋にAIを着席させる。AIはstreamを持たないので、参加者としてはbotsで管理する
+func (h *GameHandler) SeatBot(roomID string, p *game.Player) error {
+	h.Lock()
+	defer h.Unlock()
+
+	g := h.game(roomID)
+	if err := g.Sit(p); err != nil {
+		return err
+	}
+	h.bots[roomID] = p
+	return nil
+}
+
+// triggerBot AIの手番であれば、別のgoroutineでAIに手を考えさせる。ロックを取った状態で呼ぶ
+func (h *GameHandler) triggerBot(roomID string, g *game.Game) {
+	bot, ok := h.bots[roomID]
+	if !ok || g.Finished() || g.Turn() != bot.Character {
+		return
+	}
+	go h.botMove(roomID, g, bot, g.Board.Clone(), g.Cursor())
+}
+
+// botMove AIに手を考えさせて打つ。思考中は他の部屋の処理を止めないよう、ロックを取らずに複製した盤面で探索する
+// cursorは探索を始めた時点の手数で、思考中に待ったなどで盤面が変わっていないかの確認に使う
+func (h *GameHandler) botMove(roomID string, g *game.Game, bot *game.Player, b game.Boarder, cursor int) {
+	x, y, err := h.engine.Move(b, bot.Character)
+	if err != nil {
+		log.Printf("bot failed to move room_id=%v: %v", roomID, err)
+		return
+	}
+
+	h.Lock()
+	defer h.Unlock()
+	// 思考中に待ったなどで盤面が変わっていたら、考えた手は使えない。
+	// 盤面を変えた側で改めてAIに考えさせているので、ここでは何もしない
+	if g.Cursor() != cursor || g.Finished() || g.Turn() != bot.Character {
+		return
+	}
+	if err := h.apply(roomID, g, x, y, bot); err != nil {
+		log.Printf("bot failed to move room_id=%v: %v", roomID, err)
+	}
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"time"
+
+	"kazuki.matsumoto/reversi/build"
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// clockTick 対局中に残り時間を通知し、時間切れを確認する間隔
+const clockTick = 1 * time.Second
+
+// startClock 先手の時計を動かし、時間切れの監視を始める。ロックを取った状態で呼ぶ
+func (h *GameHandler) startClock(roomID string, g *game.Game) {
+	clock, ok := h.clocks[roomID]
+	if !ok {
+		return
+	}
+	clock.Run(g.Turn())
+	go h.watchClock(roomID, g, clock)
+}
+
+// watchClock 対局が終わるまで、一定間隔で残り時間を通知し、時間切れになっていれば負けとする
+func (h *GameHandler) watchClock(roomID string, g *game.Game, clock *game.Clock) {
+	ticker := time.NewTicker(clockTick)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !h.tick(roomID, g, clock) {
+			return
+		}
+	}
+}
+
+// tick 時間切れを確認し、残り時間を通知する。対局が終わっていればfalse
+func (h *GameHandler) tick(roomID string, g *game.Game, clock *game.Clock) bool {
+	h.Lock()
+	defer h.Unlock()
+
+	if g.Finished() {
+		return false
+	}
+	if c := clock.Flagged(); c != game.None {
+		h.timeUp(roomID, g, c)
+		return false
+	}
+	h.broadcast(roomID, &pb.PlayResponse{
+		Event: &pb.PlayResponse_Clock{
+			Clock: &pb.PlayResponse_ClockEvent{
+				Clocks: build.PBClocks(clock),
+			},
+		},
+	})
+	return true
+}
+
+// timeUp 持ち時間を使い切った色の負けとして対局を終了し、参加者全員に通知する。ロックを取った状態で呼ぶ
+func (h *GameHandler) timeUp(roomID string, g *game.Game, c game.Character) {
+	g.TimeUp(c)
+	h.finish(roomID, g)
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"fmt"
+
+	"kazuki.matsumoto/reversi/build"
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+	"kazuki.matsumoto/reversi/server/storage"
+)
+
+// resign 投了する
+func (h *GameHandler) resign(stream pb.GameService_PlayServer, roomID string, req *game.Player) error {
+	h.Lock()
+	defer h.Unlock()
+
+	g, p, err := h.seated(stream, roomID, req)
+	if err != nil {
+		return reject(stream, err)
+	}
+	// 開始前は投了ではなく中止する
+	if !g.Started() {
+		return sendError(stream, pb.PlayResponse_ErrorEvent_INVALID_ACTION, "game has not started")
+	}
+	if err := g.Resign(p.Character); err != nil {
+		return reject(stream, err)
+	}
+	h.finish(roomID, g)
+	return nil
+}
+
+// abort 対局を中止する。自分の初手を打つ前のみ中止でき、勝敗はつかない
+func (h *GameHandler) abort(stream pb.GameService_PlayServer, roomID string, req *game.Player) error {
+	h.Lock()
+	defer h.Unlock()
+
+	g, p, err := h.seated(stream, roomID, req)
+	if err != nil {
+		return reject(stream, err)
+	}
+	if err := g.Abort(p.Character); err != nil {
+		return reject(stream, err)
+	}
+	h.finish(roomID, g)
+	return nil
+}
+
+// offerDraw 引き分けを申し込む。相手がAIの場合はその場で断られる
+func (h *GameHandler) offerDraw(stream pb.GameService_PlayServer, roomID string, req *game.Player) error {
+	h.Lock()
+	defer h.Unlock()
+
+	g, p, err := h.seated(stream, roomID, req)
+	if err != nil {
+		return reject(stream, err)
+	}
+	if g.Finished() {
+		return reject(stream, game.ErrGameFinished)
+	}
+	if !g.Started() {
+		return sendError(stream, pb.PlayResponse_ErrorEvent_INVALID_ACTION, "game has not started")
+	}
+	if _, ok := h.draws[roomID]; ok {
+		return sendError(stream, pb.PlayResponse_ErrorEvent_INVALID_ACTION, "draw already offered")
+	}
+
+	if bot, ok := h.bots[roomID]; ok && bot.Character != p.Character {
+		h.broadcast(roomID, drawDeclined())
+		return nil
+	}
+
+	h.draws[roomID] = p.Character
+	h.broadcast(roomID, &pb.PlayResponse{
+		Event: &pb.PlayResponse_DrawOffered{
+			DrawOffered: &pb.PlayResponse_DrawOfferedEvent{
+				Player: build.PBPlayer(p),
+			},
+		},
+	})
+	return nil
+}
+
+// drawReply 相手からの引き分けの申し込みに返答する
+func (h *GameHandler) drawReply(stream pb.GameService_PlayServer, roomID string, req *game.Player, accept bool) error {
+	h.Lock()
+	defer h.Unlock()
+
+	g, p, err := h.seated(stream, roomID, req)
+	if err != nil {
+		return reject(stream, err)
+	}
+	// 自分で申し込んだ引き分けには返答できない
+	offerer, ok := h.draws[roomID]
+	if !ok || offerer == p.Character {
+		return sendError(stream, pb.PlayResponse_ErrorEvent_INVALID_ACTION, "no draw offer to reply")
+	}
+	delete(h.draws, roomID)
+
+	if !accept {
+		h.broadcast(roomID, drawDeclined())
+		return nil
+	}
+	if err := g.AgreeDraw(); err != nil {
+		return reject(stream, err)
+	}
+	h.finish(roomID, g)
+	return nil
+}
+
+// cancelDraw 返答待ちの引き分けの申し込みがあれば、断られたものとして取り下げる。ロックを取った状態で呼ぶ
+func (h *GameHandler) cancelDraw(roomID string) {
+	if _, ok := h.draws[roomID]; !ok {
+		return
+	}
+	delete(h.draws, roomID)
+	h.broadcast(roomID, drawDeclined())
+}
+
+func drawDeclined() *pb.PlayResponse {
+	return &pb.PlayResponse{
+		Event: &pb.PlayResponse_DrawDeclined{
+			DrawDeclined: &pb.PlayResponse_DrawDeclinedEvent{},
+		},
+	}
+}
+
+// finish 手を打つ以外の理由で終了した対局の後始末をし、結果を参加者全員に通知する。ロックを取った状態で呼ぶ
+func (h *GameHandler) finish(roomID string, g *game.Game) {
+	if clock, ok := h.clocks[roomID]; ok {
+		clock.Stop()
+	}
+	// 返答待ちの申し込みは意味がなくなるので、通知せずに破棄する
+	delete(h.pending, roomID)
+	delete(h.draws, roomID)
+	h.save(roomID, g)
+	grant := h.settle(roomID, g)
+	fmt.Printf("game has finished room_id=%v termination=%v\n", roomID, build.PBTermination(g.Termination()))
+
+	h.broadcast(roomID, h.finishedEvent(g, grant))
+}
+
+// finishedEvent 対局の結果と、勝ったプレイヤーに付与した報酬
+func (h *GameHandler) finishedEvent(g *game.Game, grant *storage.Grant) *pb.PlayResponse {
+	finished := build.PBFinishedEvent(g)
+	finished.Reward = h.rewards.pbReward(grant)
+	return &pb.PlayResponse{
+		Event: &pb.PlayResponse_Finished{
+			Finished: finished,
+		},
+	}
+}
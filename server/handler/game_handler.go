@@ -1,31 +1,86 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"kazuki.matsumoto/reversi/build"
 	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/game/ai"
 	"kazuki.matsumoto/reversi/gen/pb"
+	"kazuki.matsumoto/reversi/server/auth"
+	"kazuki.matsumoto/reversi/server/storage"
+	"log"
 	"sync"
+	"time"
 )
 
 type GameHandler struct {
 	pb.UnimplementedGameServiceServer
 	sync.RWMutex
-	games  map[int32]*game.Game                  // ゲーム情報(盤面など)を格納
-	client map[int32][]pb.GameService_PlayServer // 状態変更時にクライアントにストリーミングを返すために格納
+	games   map[string]*game.Game                  // ゲーム情報(盤面など)を格納
+	client  map[string][]pb.GameService_PlayServer // 状態変更時にクライアントにストリーミングを返すために格納
+	players map[pb.GameService_PlayServer]*seat    // streamごとの着席情報。手を打つ際はリクエストの内容ではなくこちらを信用する
+	bots    map[string]*game.Player                // AIが着席している部屋と、そのAIのプレイヤー
+	pending map[string]game.Character              // 返答待ちの待ったがある部屋と、申し込んだ色
+	draws   map[string]game.Character              // 返答待ちの引き分けの申し込みがある部屋と、申し込んだ色
+	clocks  map[string]*game.Clock                 // 持ち時間がある部屋の対局時計
+	// 観戦者のstream。通知は受け取るが着席していないので手は打てない
+	spectators map[string][]pb.GameService_PlayServer
+	watching   map[pb.GameService_PlayServer]string // 観戦者のstreamと観戦している部屋
+	closed     map[string]bool                      // 観戦を許可していない部屋
+	touched    map[string]time.Time                 // 対局を準備した時刻。終了したら終了した時刻。片付けるまでの猶予を数える
+	away       map[*game.Player]time.Time           // 対局中に接続が切れ、再接続を待っているプレイヤーと切れた時刻
+	now        func() time.Time
+	engine     *ai.Engine
+	registry   *Registry     // マッチングした部屋。着席や観戦の前に、部屋があるかをここで確かめる
+	store      storage.Store // 対局の記録の保存先
+	profiles   *Profiles     // 対局が終了したらレーティングを更新する
+	rewards    *Rewards      // 対局が終了したら勝ったプレイヤーに報酬を付与する
+}
+
+// seat streamが着席している部屋とプレイヤー
+type seat struct {
+	roomID string
+	player *game.Player
 }
 
 const RoomJoinNum = 2
 
-func NewGameHandler() *GameHandler {
+func NewGameHandler(registry *Registry, store storage.Store, profiles *Profiles, rewards *Rewards) *GameHandler {
 	return &GameHandler{
-		games:  make(map[int32]*game.Game),
-		client: make(map[int32][]pb.GameService_PlayServer),
+		games:      make(map[string]*game.Game),
+		client:     make(map[string][]pb.GameService_PlayServer),
+		players:    make(map[pb.GameService_PlayServer]*seat),
+		bots:       make(map[string]*game.Player),
+		pending:    make(map[string]game.Character),
+		draws:      make(map[string]game.Character),
+		clocks:     make(map[string]*game.Clock),
+		spectators: make(map[string][]pb.GameService_PlayServer),
+		watching:   make(map[pb.GameService_PlayServer]string),
+		closed:     make(map[string]bool),
+		touched:    make(map[string]time.Time),
+		away:       make(map[*game.Player]time.Time),
+		now:        time.Now,
+		engine:     ai.NewEngine(ai.DefaultConfig()),
+		registry:   registry,
+		store:      store,
+		profiles:   profiles,
+		rewards:    rewards,
 	}
 }
 
 // Play エントリーポイント。streamの中のActionによって処理が振り分けられる
 func (h *GameHandler) Play(stream pb.GameService_PlayServer) error {
+	// streamが終了したら通知先から外す。席は残しておくので、同じトークンで再接続すれば戻れる
+	defer func() {
+		h.Lock()
+		h.detach(stream)
+		h.Unlock()
+	}()
+
+	// 着席するプレイヤーと部屋は、リクエストの内容ではなくinterceptorで確かめたトークンから決める
+	id, authenticated := auth.FromContext(stream.Context())
+
 	for {
 		// クライアントからリクエストを受信したら、reqにリクエストが代入
 		req, err := stream.Recv()
@@ -33,16 +88,28 @@ func (h *GameHandler) Play(stream pb.GameService_PlayServer) error {
 			return err
 		}
 
-		// TODO この辺りをメモリ or interceptorから受け取る
-		roomID := req.GetRoomId()
-		player := build.Player(req.GetPlayer())
+		// 観戦はマッチングしていなくてもできるので、トークンなしでリクエストの部屋を観戦する
+		if _, ok := req.GetAction().(*pb.PlayRequest_Watch); ok {
+			if err := h.watch(stream, req.GetRoomId()); err != nil {
+				return err
+			}
+			continue
+		}
+		if !authenticated {
+			if err := reject(stream, errUnauthenticated); err != nil {
+				return err
+			}
+			continue
+		}
+		roomID := id.RoomID
+		player := id.Player()
 
 		// oneofで複数の型のリクエストがくるので、switch文で処理
 		// TODO: oneof使うとこういうことになるのであんまやりたくないね
 		switch req.GetAction().(type) {
 		case *pb.PlayRequest_Start:
 			// ゲーム開始リクエスト
-			err := h.start(stream, roomID)
+			err := h.start(stream, roomID, player)
 			if err != nil {
 				return err
 			}
@@ -51,7 +118,49 @@ func (h *GameHandler) Play(stream pb.GameService_PlayServer) error {
 			action := req.GetMove()
 			x := action.GetMove().GetX()
 			y := action.GetMove().GetY()
-			err := h.move(roomID, x, y, player)
+			err := h.move(stream, roomID, x, y, player)
+			if err != nil {
+				return err
+			}
+		case *pb.PlayRequest_Resume:
+			// 通信が切れたクライアントの再接続
+			err := h.resume(stream, roomID, player)
+			if err != nil {
+				return err
+			}
+		case *pb.PlayRequest_Resign:
+			// 投了
+			err := h.resign(stream, roomID, player)
+			if err != nil {
+				return err
+			}
+		case *pb.PlayRequest_OfferDraw:
+			// 引き分けの申し込み
+			err := h.offerDraw(stream, roomID, player)
+			if err != nil {
+				return err
+			}
+		case *pb.PlayRequest_DrawReply:
+			// 引き分けへの返答
+			err := h.drawReply(stream, roomID, player, req.GetDrawReply().GetAccept())
+			if err != nil {
+				return err
+			}
+		case *pb.PlayRequest_Abort:
+			// 初手を打つ前の中止
+			err := h.abort(stream, roomID, player)
+			if err != nil {
+				return err
+			}
+		case *pb.PlayRequest_Takeback:
+			// 待ったの申し込み
+			err := h.takeback(stream, roomID, player)
+			if err != nil {
+				return err
+			}
+		case *pb.PlayRequest_TakebackReply:
+			// 待ったへの返答
+			err := h.takebackReply(stream, roomID, player, req.GetTakebackReply().GetAccept())
 			if err != nil {
 				return err
 			}
@@ -59,36 +168,57 @@ func (h *GameHandler) Play(stream pb.GameService_PlayServer) error {
 	}
 }
 
-func (h *GameHandler) start(stream pb.GameService_PlayServer, roomID int32) error {
+func (h *GameHandler) start(stream pb.GameService_PlayServer, roomID string, p *game.Player) error {
 	h.Lock()
 	defer h.Unlock()
 
-	// mutexでロックしたいので、読み込みを一回にするためにメモ化
-	g := h.games[roomID]
+	// 同じstreamで二度着席することはできない
+	if _, ok := h.players[stream]; ok {
+		return sendError(stream, pb.PlayResponse_ErrorEvent_INVALID_PLAYER, "already started")
+	}
+	// 観戦中のstreamでは対局に参加できない
+	if _, ok := h.watching[stream]; ok {
+		return sendError(stream, pb.PlayResponse_ErrorEvent_INVALID_PLAYER, "spectator can not play")
+	}
 
-	// ゲーム情報がなければ作成する
-	if g == nil {
-		g = game.NewGame(game.None) // gameのインスタンス生成
-		h.games[roomID] = g
-		h.client[roomID] = make([]pb.GameService_PlayServer, 0, RoomJoinNum) // 2人分のstreamを格納し、clientに状態変更の通知をする準備をする
+	// マッチングした部屋の、ホストかゲストとしてマッチングしたプレイヤーだけが着席できる
+	room, ok := h.registry.Room(roomID)
+	if !ok {
+		return reject(stream, ErrRoomNotFound)
+	}
+	if !member(room, p) {
+		return sendError(stream, pb.PlayResponse_ErrorEvent_INVALID_PLAYER, "not a member of the room")
 	}
+	g := h.game(roomID)
+
+	// 色ごとの席に着席。すでに埋まっている色には座れない
+	if err := g.Sit(p); err != nil {
+		return reject(stream, err)
+	}
+	h.players[stream] = &seat{roomID: roomID, player: p}
 
 	// 自分のクライアントを格納
 	h.client[roomID] = append(h.client[roomID], stream)
 
-	if len(h.client[roomID]) == RoomJoinNum {
-		// 二人揃ったので開始。参加者全員のclientにブロードキャスト
-		for _, s := range h.client[roomID] {
-			err := s.Send(&pb.PlayResponse{
-				Event: &pb.PlayResponse_Ready{
-					Ready: &pb.PlayResponse_ReadyEvent{},
-				},
-			})
-			if err != nil {
-				return err
-			}
-		}
+	// AIが着席している場合は、AIも参加者として数える
+	joined := len(h.client[roomID])
+	if _, ok := h.bots[roomID]; ok {
+		joined++
+	}
+
+	if joined == RoomJoinNum {
+		// 二人揃ったので開始。参加者全員と観戦者にブロードキャスト
+		h.broadcast(roomID, &pb.PlayResponse{
+			Event: &pb.PlayResponse_Ready{
+				Ready: &pb.PlayResponse_ReadyEvent{},
+			},
+		})
+		g.Start()
 		fmt.Printf("game has started room_id=%v\n", roomID)
+		h.startClock(roomID, g)
+		h.save(roomID, g)
+		// AIが先手の場合はAIから打つ
+		h.triggerBot(roomID, g)
 	} else {
 		//まだroomが全員揃ってないので、待機中であることをクライアントに通知
 		err := stream.Send(&pb.PlayResponse{
@@ -103,53 +233,275 @@ func (h *GameHandler) start(stream pb.GameService_PlayServer, roomID int32) erro
 	return nil
 }
 
-func (h *GameHandler) move(roomID int32, x int32, y int32, p *game.Player) error {
+// PrepareRoom 部屋の対局を作成し、部屋の設定を反映する。持ち時間があれば時計を用意し、対局開始時に動かす
+func (h *GameHandler) PrepareRoom(room *game.Room) {
 	h.Lock()
-
-	// TODO 終了した時、ここでクライアントやGame構造体のmapからGameの内容を削除する処理を入れる
 	defer h.Unlock()
+
+	h.game(room.ID)
+	h.configure(room)
+}
+
+// configure 部屋の設定に合わせて、時計と観戦の可否を用意する。ロックを取った状態で呼ぶ
+func (h *GameHandler) configure(room *game.Room) {
+	if room.TimeControl.Enabled() {
+		h.clocks[room.ID] = game.NewClock(room.TimeControl)
+	}
+	if !room.AllowSpectators {
+		h.closed[room.ID] = true
+	}
+}
+
+// member pがroomのホストかゲストとしてマッチングしたプレイヤーか
+func member(room *game.Room, p *game.Player) bool {
+	for _, m := range []*game.Player{room.Host, room.Guest} {
+		if m != nil && m.ID == p.ID && m.Character == p.Character {
+			return true
+		}
+	}
+	return false
+}
+
+// game 部屋のゲーム情報を返す。なければ作成する。ロックを取った状態で呼ぶ
+func (h *GameHandler) game(roomID string) *game.Game {
 	// mutexでロックしたいので、読み込みを一回にするためにメモ化
 	g := h.games[roomID]
 
+	// ゲーム情報がなければ作成する
+	if g == nil {
+		g = game.NewGameWithBoard(game.None, game.NewBitBoard()) // gameのインスタンス生成。サーバーでは高速なBitBoardを使う
+		h.games[roomID] = g
+		h.client[roomID] = make([]pb.GameService_PlayServer, 0, RoomJoinNum) // 2人分のstreamを格納し、clientに状態変更の通知をする準備をする
+		h.touched[roomID] = h.now()
+	}
+	return g
+}
+
+// seated streamに紐づく着席プレイヤーと、その部屋のゲームを返す。ロックを取った状態で呼ぶ
+// 操作するプレイヤーはリクエストではなく、着席時にstreamと紐付けたプレイヤーとする
+func (h *GameHandler) seated(stream pb.GameService_PlayServer, roomID string, req *game.Player) (*game.Game, *game.Player, error) {
+	st, ok := h.players[stream]
+	if !ok {
+		return nil, nil, errNotSeated
+	}
+	// 片付けられた部屋では操作できない
+	g := h.games[roomID]
+	if g == nil {
+		return nil, nil, ErrRoomNotFound
+	}
+	// 別の部屋や別のプレイヤーを名乗っている場合はなりすましとして拒否
+	p := st.player
+	if st.roomID != roomID || g.Seated(p.Character) != p || req.ID != p.ID || req.Character != p.Character {
+		return nil, nil, errPlayerMismatch
+	}
+	return g, p, nil
+}
+
+func (h *GameHandler) move(stream pb.GameService_PlayServer, roomID string, x int32, y int32, req *game.Player) error {
+	h.Lock()
+	defer h.Unlock()
+
+	g, p, err := h.seated(stream, roomID, req)
+	if err != nil {
+		return reject(stream, err)
+	}
+
+	if g.Finished() {
+		return reject(stream, game.ErrGameFinished)
+	}
+	// 相手が着席する前に打ち進められないようにする
+	if !g.Started() {
+		return sendError(stream, pb.PlayResponse_ErrorEvent_INVALID_ACTION, "game has not started")
+	}
+	if g.Turn() != p.Character {
+		return reject(stream, game.ErrNotYourTurn)
+	}
+
+	// 置けない場所への手は、打った側にだけ知らせる。streamは維持するので打ち直せる
+	err = h.apply(roomID, g, x, y, p)
+	if errors.Is(err, game.ErrIllegalMove) {
+		return reject(stream, err)
+	}
+	return err
+}
+
+// apply 手を打ち、参加者全員に通知する。ロックを取った状態で呼ぶ
+func (h *GameHandler) apply(roomID string, g *game.Game, x int32, y int32, p *game.Player) error {
+	// 時間切れの監視より先に手が届いても、持ち時間を過ぎていれば負けとする
+	clock := h.clocks[roomID]
+	if clock != nil && clock.Flagged() == p.Character {
+		h.timeUp(roomID, g, p.Character)
+		return nil
+	}
+
 	finished, err := g.Move(x, y, p.Character)
 	if err != nil {
 		return err
 	}
 
-	for _, s := range h.client[roomID] {
-		// 手が打たれたことをクライアントに通知
-		err := s.Send(&pb.PlayResponse{
-			Event: &pb.PlayResponse_Move{
-				Move: &pb.PlayResponse_MoveEvent{
-					Player: build.PBPlayer(p),
-					Move: &pb.Move{
-						X: x,
-						Y: y,
-					},
-					Board: build.PBBoard(g.Board),
+	// 返答を待っている待ったは、手が進んだので取り下げる
+	if err := h.cancelTakeback(roomID); err != nil {
+		return err
+	}
+	// 引き分けの申し込みは、申し込まれた側が手を打ったら取り下げる
+	if offerer, ok := h.draws[roomID]; ok && (finished || offerer != p.Character) {
+		h.cancelDraw(roomID)
+	}
+
+	// 次の手番の色がどこにも置けない場合は自動でパスする
+	var passed *game.Player
+	if !finished && g.MustPass() {
+		passed = g.Seated(g.Turn())
+		if err := g.Pass(g.Turn()); err != nil {
+			return err
+		}
+	}
+
+	// 打った側の時計を止め、次の手番の時計を動かす
+	if clock != nil {
+		clock.Press()
+		if !finished {
+			clock.Run(g.Turn())
+		}
+	}
+
+	h.save(roomID, g)
+	var grant *storage.Grant
+	if finished {
+		grant = h.settle(roomID, g)
+	}
+
+	// 手が打たれたこと、パスされたこと、ゲーム終了を順に通知
+	events := []*pb.PlayResponse{{
+		Event: &pb.PlayResponse_Move{
+			Move: &pb.PlayResponse_MoveEvent{
+				Player: build.PBPlayer(p),
+				Move: &pb.Move{
+					X: x,
+					Y: y,
+				},
+				Board:  build.PBBoard(g.Board),
+				Clocks: build.PBClocks(clock),
+			},
+		},
+	}}
+	if passed != nil {
+		events = append(events, &pb.PlayResponse{
+			Event: &pb.PlayResponse_Pass{
+				Pass: &pb.PlayResponse_PassEvent{
+					Player: build.PBPlayer(passed),
 				},
 			},
 		})
-		if err != nil {
-			return err
+	}
+	if finished {
+		events = append(events, h.finishedEvent(g, grant))
+	}
+	for _, res := range events {
+		h.broadcast(roomID, res)
+	}
+	// 次がAIの手番であればAIに打たせる
+	if !finished {
+		h.triggerBot(roomID, g)
+	}
+	return nil
+}
+
+// save 対局の状態を保存する。保存に失敗しても対局は続ける。ロックを取った状態で呼ぶ
+func (h *GameHandler) save(roomID string, g *game.Game) {
+	if err := h.store.SaveGame(roomID, g, h.clocks[roomID]); err != nil {
+		log.Printf("failed to save game room_id=%v: %v", roomID, err)
+	}
+}
+
+// rate 終了した対局の結果でレーティングを更新する。更新に失敗しても結果の通知は続ける
+func (h *GameHandler) rate(roomID string, g *game.Game) {
+	if err := h.profiles.RecordResult(roomID, g); err != nil {
+		log.Printf("failed to update ratings room_id=%v: %v", roomID, err)
+	}
+}
+
+// reward 終了した対局に勝ったプレイヤーに報酬を付与する。付与に失敗しても結果の通知は続ける
+func (h *GameHandler) reward(roomID string, g *game.Game) *storage.Grant {
+	grant, err := h.rewards.Grant(roomID, g)
+	if err != nil {
+		log.Printf("failed to grant reward room_id=%v: %v", roomID, err)
+	}
+	return grant
+}
+
+var (
+	errNotSeated       = errors.New("not seated")
+	errPlayerMismatch  = errors.New("player mismatch")
+	errUnauthenticated = errors.New("unauthenticated")
+)
+
+// errorCode エラーに対応するErrorEventのコード。クライアントがエラーの種類で対応を変えられるようにする
+func errorCode(err error) pb.PlayResponse_ErrorEvent_Code {
+	switch {
+	case errors.Is(err, game.ErrIllegalMove):
+		return pb.PlayResponse_ErrorEvent_ILLEGAL_MOVE
+	case errors.Is(err, game.ErrNotYourTurn):
+		return pb.PlayResponse_ErrorEvent_NOT_YOUR_TURN
+	case errors.Is(err, game.ErrGameFinished):
+		return pb.PlayResponse_ErrorEvent_GAME_FINISHED
+	case errors.Is(err, ErrRoomNotFound):
+		return pb.PlayResponse_ErrorEvent_UNKNOWN_ROOM
+	case errors.Is(err, errUnauthenticated):
+		return pb.PlayResponse_ErrorEvent_UNAUTHENTICATED
+	case errors.Is(err, errNotSeated), errors.Is(err, errPlayerMismatch),
+		errors.Is(err, game.ErrSeatTaken), errors.Is(err, game.ErrInvalidCharacter):
+		return pb.PlayResponse_ErrorEvent_INVALID_PLAYER
+	}
+	return pb.PlayResponse_ErrorEvent_INVALID_ACTION
+}
+
+// reject リクエストを受け付けられなかった理由を、送ったクライアントにのみ通知する。streamは維持する
+func reject(stream pb.GameService_PlayServer, err error) error {
+	return sendError(stream, errorCode(err), err.Error())
+}
+
+// sendError リクエストを送ったクライアントにのみエラーを通知する。streamは維持する
+func sendError(stream pb.GameService_PlayServer, code pb.PlayResponse_ErrorEvent_Code, msg string) error {
+	return stream.Send(&pb.PlayResponse{
+		Event: &pb.PlayResponse_Error{
+			Error: &pb.PlayResponse_ErrorEvent{
+				Code:    code,
+				Message: msg,
+			},
+		},
+	})
+}
+
+// broadcast 部屋の参加者全員と観戦者に通知する。ロックを取った状態で呼ぶ
+// 一人への送信に失敗しても、他の参加者には送る。切れたstreamはそのstreamのPlayが終わる時に外れる
+func (h *GameHandler) broadcast(roomID string, res *pb.PlayResponse) {
+	for _, s := range h.client[roomID] {
+		if err := s.Send(res); err != nil {
+			log.Printf("failed to send to player room_id=%v: %v", roomID, err)
 		}
+	}
+	h.spectate(roomID, res)
+}
 
-		if finished {
-			// ゲーム終了を通知
-			err := s.Send(
-				&pb.PlayResponse{
-					Event: &pb.PlayResponse_Finished{
-						Finished: &pb.PlayResponse_FinishedEvent{
-							Winner: build.PBCharacter(g.Winner()),
-							Board:  build.PBBoard(g.Board),
-						},
-					},
-				},
-			)
-			if err != nil {
-				return err
-			}
+// detach streamを着席情報、通知先、観戦者から外す。対局中のプレイヤーなら再接続を待つ。ロックを取った状態で呼ぶ
+func (h *GameHandler) detach(stream pb.GameService_PlayServer) {
+	h.unwatch(stream)
+
+	st, ok := h.players[stream]
+	if !ok {
+		return
+	}
+	delete(h.players, stream)
+
+	streams := h.client[st.roomID]
+	for i, s := range streams {
+		if s == stream {
+			h.client[st.roomID] = append(streams[:i], streams[i+1:]...)
+			break
 		}
 	}
-	return nil
+	if g := h.games[st.roomID]; g != nil {
+		h.leave(st.roomID, g, st.player)
+	}
 }
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"testing"
+
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// TestMoveBeforeStart 相手が着席する前に、着席したホストが手を打てない
+func TestMoveBeforeStart(t *testing.T) {
+	m := newTestMatchingHandler(t)
+	h := m.tables.(*GameHandler)
+	black := &game.Player{ID: "black", Name: "black", Character: game.Black}
+	white := &game.Player{ID: "white", Name: "white", Character: game.White}
+	m.Lock()
+	room, err := m.openRoom(&game.Room{Host: black, Guest: white})
+	m.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.PrepareRoom(room)
+
+	host := &testPlayStream{}
+	if err := h.start(host, room.ID, black); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.move(host, room.ID, 6, 5, black); err != nil {
+		t.Fatal(err)
+	}
+	if host.last().GetError().GetCode() != pb.PlayResponse_ErrorEvent_INVALID_ACTION {
+		t.Errorf("move before start = %v, want INVALID_ACTION", host.last())
+	}
+	if n := h.games[room.ID].Len(); n != 0 {
+		t.Fatalf("%d plies played before start, want 0", n)
+	}
+
+	// 二人揃えば打てる
+	if err := h.start(&testPlayStream{}, room.ID, white); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.move(host, room.ID, 6, 5, black); err != nil {
+		t.Fatal(err)
+	}
+	if e := host.last().GetError(); e != nil {
+		t.Errorf("move after start = %v", e)
+	}
+	if n := h.games[room.ID].Len(); n != 1 {
+		t.Errorf("%d plies played after start, want 1", n)
+	}
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"kazuki.matsumoto/reversi/build"
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+const (
+	inviteCodeLength = 6
+	// inviteCodeAlphabet 招待コードに使う文字。口頭で伝えやすいよう、0とO、1とIとLのような紛らわしい文字を除く
+	inviteCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	// inviteWaitTime 招待した相手が参加するまで待つ時間
+	inviteWaitTime = 10 * time.Minute
+)
+
+// invite CreateRoomで作成され、ゲストを待っている部屋
+type invite struct {
+	room   *game.Room
+	color  game.Character // ホストが希望する色。Noneならゲストが参加した時にランダムに決める
+	opened time.Time      // 部屋を作成した時刻。部屋の一覧はこの順に並べる
+	joined chan struct{}  // ゲストが参加したら閉じる。待っているホストをすぐに起こすために使う
+}
+
+// CreateRoom 招待コード付きの部屋を作成し、ゲストが参加するまで待つ
+func (h *MatchingHandler) CreateRoom(req *pb.CreateRoomRequest, stream pb.MatchingService_CreateRoomServer) error {
+	ctx, cancel := context.WithTimeout(stream.Context(), inviteWaitTime)
+	defer cancel()
+
+	settings := req.GetSettings()
+	tc := build.TimeControl(settings.GetTimeControl())
+	if err := tc.Validate(); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	variant := build.Variant(settings.GetVariant())
+	if err := variant.Validate(); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	prof, err := h.register(req.GetName(), req.GetPlayerToken())
+	if err != nil {
+		return err
+	}
+	// 色はゲストが参加してから決める
+	host := &game.Player{
+		ID:        prof.ID,
+		Character: game.None,
+		Name:      prof.Name,
+	}
+
+	h.Lock()
+	code, err := h.newInviteCode()
+	if err != nil {
+		h.Unlock()
+		return err
+	}
+	room, err := h.openRoom(&game.Room{
+		Host:            host,
+		TimeControl:     tc,
+		Variant:         variant,
+		Visibility:      build.Visibility(settings.GetVisibility()),
+		AllowSpectators: settings.GetAllowSpectators(),
+		InviteCode:      code,
+	})
+	if err != nil {
+		h.Unlock()
+		return err
+	}
+	inv := &invite{
+		room:   room,
+		color:  preferredColor(req.GetColor()),
+		opened: time.Now(),
+		joined: make(chan struct{}),
+	}
+	h.invites[code] = inv
+	// ゲストが参加すると部屋が書き換わるので、ロックを取っている間に送る内容を作る
+	waiting := &pb.JoinRoomResponse{
+		Status: pb.JoinRoomResponse_WAITING,
+		Room:   build.PBRoom(room),
+	}
+	h.Unlock()
+	// ゲストが参加しないまま抜ける場合は、招待を取り消す
+	defer h.cancelInvite(code)
+
+	if err := stream.Send(waiting); err != nil {
+		return err
+	}
+
+	// ゲストが参加するとinv.joinedが閉じられるので、すぐに起きる
+	select {
+	case <-inv.joined:
+		return h.matched(stream, room, host)
+	case <-ctx.Done():
+		return status.Errorf(codes.DeadlineExceeded, "招待した相手が参加しませんでした。")
+	}
+}
+
+// JoinRoomByCode 招待コードの部屋にゲストとして参加する。ホストはすでに待っているので、すぐにマッチングする
+func (h *MatchingHandler) JoinRoomByCode(ctx context.Context, req *pb.JoinRoomByCodeRequest) (*pb.JoinRoomResponse, error) {
+	prof, err := h.register(req.GetName(), req.GetPlayerToken())
+	if err != nil {
+		return nil, err
+	}
+	me := &game.Player{
+		ID:   prof.ID,
+		Name: prof.Name,
+	}
+
+	h.Lock()
+	code := strings.ToUpper(strings.TrimSpace(req.GetInviteCode()))
+	inv, ok := h.invites[code]
+	if !ok {
+		h.Unlock()
+		return nil, status.Errorf(codes.NotFound, "room not found")
+	}
+	room := inv.room
+	if room.Host.ID == me.ID {
+		h.Unlock()
+		return nil, status.Errorf(codes.FailedPrecondition, "can not join your own room")
+	}
+	h.queue.assignColors(room.Host, inv.color, me, game.None)
+	room.Guest = me
+	h.tables.PrepareRoom(room)
+	delete(h.invites, code)
+	close(inv.joined)
+	h.saveRoom(room)
+	h.Unlock()
+
+	return h.matchedResponse(room, me)
+}
+
+// ListRooms ゲストを待っている公開の部屋を、作成された順に返す
+func (h *MatchingHandler) ListRooms(ctx context.Context, req *pb.ListRoomsRequest) (*pb.ListRoomsResponse, error) {
+	h.RLock()
+	defer h.RUnlock()
+
+	invites := make([]*invite, 0, len(h.invites))
+	for _, inv := range h.invites {
+		if inv.room.Visibility == game.Public {
+			invites = append(invites, inv)
+		}
+	}
+	sort.Slice(invites, func(i, j int) bool {
+		return invites[i].opened.Before(invites[j].opened)
+	})
+
+	res := &pb.ListRoomsResponse{
+		Rooms: make([]*pb.Room, 0, len(invites)),
+	}
+	for _, inv := range invites {
+		res.Rooms = append(res.Rooms, build.PBRoom(inv.room))
+	}
+	return res, nil
+}
+
+// cancelInvite ゲストが参加していなければ、招待を取り消して部屋を閉じる
+func (h *MatchingHandler) cancelInvite(code string) {
+	h.Lock()
+	defer h.Unlock()
+
+	inv, ok := h.invites[code]
+	if !ok {
+		return
+	}
+	delete(h.invites, code)
+	h.registry.Close(inv.room.ID)
+}
+
+// newInviteCode 使われていない招待コードを作る。推測されないよう乱数で作る。ロックを取った状態で呼ぶ
+func (h *MatchingHandler) newInviteCode() (string, error) {
+	// 使われているコードと重なったら作り直す。部屋の数に対してコードの種類は十分に多いので、すぐに見つかる
+	for i := 0; i < 10; i++ {
+		var b strings.Builder
+		for j := 0; j < inviteCodeLength; j++ {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(inviteCodeAlphabet))))
+			if err != nil {
+				return "", err
+			}
+			b.WriteByte(inviteCodeAlphabet[n.Int64()])
+		}
+		if _, ok := h.invites[b.String()]; !ok {
+			return b.String(), nil
+		}
+	}
+	return "", errors.New("failed to generate invite code")
+}
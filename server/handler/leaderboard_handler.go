@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"kazuki.matsumoto/reversi/gen/pb"
+	"kazuki.matsumoto/reversi/server/storage"
+)
+
+type LeaderboardHandler struct {
+	pb.UnimplementedLeaderboardServiceServer
+	profiles *Profiles
+}
+
+const (
+	defaultTopLimit = 10
+	maxTopLimit     = 100
+)
+
+func NewLeaderboardHandler(profiles *Profiles) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		profiles: profiles,
+	}
+}
+
+func (h *LeaderboardHandler) Top(ctx context.Context, req *pb.TopRequest) (*pb.TopResponse, error) {
+	limit := int(req.GetLimit())
+	if limit < 0 || maxTopLimit < limit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 0 and %d", maxTopLimit)
+	}
+	if limit == 0 {
+		limit = defaultTopLimit
+	}
+
+	ranked, err := h.profiles.Ranking()
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.TopResponse{
+		Profiles: make([]*pb.Profile, 0, min(limit, len(ranked))),
+		Total:    int32(len(ranked)),
+	}
+	for i, prof := range ranked {
+		if i >= limit {
+			break
+		}
+		res.Profiles = append(res.Profiles, pbProfile(prof, i+1))
+	}
+	return res, nil
+}
+
+func (h *LeaderboardHandler) GetRank(ctx context.Context, req *pb.GetRankRequest) (*pb.GetRankResponse, error) {
+	prof, err := findProfile(h.profiles, req.GetPlayerId(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ranked, err := h.profiles.Ranking()
+	if err != nil {
+		return nil, err
+	}
+
+	rank := 0
+	for i, r := range ranked {
+		if r.ID == prof.ID {
+			rank = i + 1
+			break
+		}
+	}
+	return &pb.GetRankResponse{
+		Profile: pbProfile(prof, rank),
+		Total:   int32(len(ranked)),
+	}, nil
+}
+
+func (h *LeaderboardHandler) GetRatingHistory(ctx context.Context, req *pb.GetRatingHistoryRequest) (*pb.GetRatingHistoryResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must not be negative")
+	}
+	prof, err := findProfile(h.profiles, req.GetPlayerId(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.GetRatingHistoryResponse{}
+	for i := len(prof.History) - 1; i >= 0; i-- {
+		if req.GetLimit() > 0 && len(res.Changes) >= int(req.GetLimit()) {
+			break
+		}
+		c := prof.History[i]
+		res.Changes = append(res.Changes, &pb.RatingChange{
+			RoomId:     c.RoomID,
+			OpponentId: c.OpponentID,
+			Score:      c.Score,
+			Rating:     c.Rating.Rating,
+			Deviation:  c.Rating.Deviation,
+			PlayedAtMs: c.PlayedAt.UnixMilli(),
+		})
+	}
+	return res, nil
+}
+
+// findProfile IDか名前でプロフィールを探す。IDが優先。RPCの応答にそのまま返せるエラーにする
+func findProfile(profiles *Profiles, id string, name string) (*storage.Profile, error) {
+	var prof *storage.Profile
+	var err error
+	switch {
+	case id != "":
+		prof, err = profiles.Profile(id)
+	case name != "":
+		prof, err = profiles.ProfileByName(name)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "player_id or name is required")
+	}
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "player not found")
+	}
+	return prof, err
+}
+
+func pbProfile(prof *storage.Profile, rank int) *pb.Profile {
+	return &pb.Profile{
+		Id:        prof.ID,
+		Name:      prof.Name,
+		Rating:    prof.Rating.Rating,
+		Deviation: prof.Rating.Deviation,
+		Wins:      int32(prof.Wins),
+		Losses:    int32(prof.Losses),
+		Draws:     int32(prof.Draws),
+		Rank:      int32(rank),
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/server/storage"
+)
+
+const (
+	// reconnectWindow 対局中に接続が切れたプレイヤーの再接続を待つ時間。戻らなければ切断した側の負けとする
+	reconnectWindow = 60 * time.Second
+	// finishedGrace 終了した対局を残しておく時間。終了直後に再接続しても結果を確認できるようにする
+	finishedGrace = 5 * time.Minute
+	// idleGrace マッチングしたのに、二人が揃わず対局が始まらない部屋を残しておく時間
+	idleGrace = 5 * time.Minute
+	// reapInterval 片付ける対局を探す間隔
+	reapInterval = 30 * time.Second
+)
+
+// Lifecycle 終了した対局や始まらない対局を定期的に片付け、Registryと再接続用のセッションからも外す
+type Lifecycle struct {
+	registry *Registry
+	games    *GameHandler
+	sessions *SessionStore
+}
+
+func NewLifecycle(registry *Registry, games *GameHandler, sessions *SessionStore) *Lifecycle {
+	return &Lifecycle{
+		registry: registry,
+		games:    games,
+		sessions: sessions,
+	}
+}
+
+// Run ctxが終わるまで、一定間隔で片付ける
+func (l *Lifecycle) Run(ctx context.Context) {
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			l.reap()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (l *Lifecycle) reap() {
+	roomIDs := l.games.reap()
+	if len(roomIDs) == 0 {
+		return
+	}
+	l.registry.Close(roomIDs...)
+	l.sessions.Revoke(roomIDs)
+	fmt.Printf("reaped rooms room_ids=%v\n", roomIDs)
+}
+
+// reap 終了してから猶予が過ぎた対局と、用意してから猶予が過ぎても始まらない対局を片付け、その部屋のIDを返す
+func (h *GameHandler) reap() []string {
+	h.Lock()
+	defer h.Unlock()
+
+	now := h.now()
+	var roomIDs []string
+	for roomID, g := range h.games {
+		since := h.touched[roomID]
+		switch {
+		case g.Finished() && now.Sub(since) >= finishedGrace:
+		case !g.Started() && now.Sub(since) >= idleGrace:
+			// 揃わなかった対局は中止として記録し、待っていたプレイヤーに知らせる
+			if err := g.Abort(game.Black); err == nil {
+				h.finish(roomID, g)
+			}
+		default:
+			continue
+		}
+		h.remove(roomID)
+		roomIDs = append(roomIDs, roomID)
+	}
+	return roomIDs
+}
+
+// remove 部屋の対局と、参加者や観戦者などの情報をすべて消す。ロックを取った状態で呼ぶ
+func (h *GameHandler) remove(roomID string) {
+	if g := h.games[roomID]; g != nil {
+		for _, c := range []game.Character{game.Black, game.White} {
+			if p := g.Seated(c); p != nil {
+				delete(h.away, p)
+			}
+		}
+	}
+	for s, st := range h.players {
+		if st.roomID == roomID {
+			delete(h.players, s)
+		}
+	}
+	for s, id := range h.watching {
+		if id == roomID {
+			delete(h.watching, s)
+		}
+	}
+	if clock, ok := h.clocks[roomID]; ok {
+		clock.Stop()
+	}
+	delete(h.games, roomID)
+	delete(h.client, roomID)
+	delete(h.bots, roomID)
+	delete(h.pending, roomID)
+	delete(h.draws, roomID)
+	delete(h.clocks, roomID)
+	delete(h.spectators, roomID)
+	delete(h.closed, roomID)
+	delete(h.touched, roomID)
+}
+
+// settle 終了した対局のレーティングを更新して報酬を付与し、片付けるまでの猶予を数え始める。ロックを取った状態で呼ぶ
+func (h *GameHandler) settle(roomID string, g *game.Game) *storage.Grant {
+	h.rate(roomID, g)
+	grant := h.reward(roomID, g)
+	h.touched[roomID] = h.now()
+	return grant
+}
+
+// leave 対局中のプレイヤーの接続が切れたので、再接続を待つ。戻らなければ負けとする。ロックを取った状態で呼ぶ
+func (h *GameHandler) leave(roomID string, g *game.Game, p *game.Player) {
+	if p.Bot || !g.Started() || g.Finished() {
+		return
+	}
+	// 同じプレイヤーの別のstreamがまだ着席していれば、切断ではない
+	for _, st := range h.players {
+		if st.player == p {
+			return
+		}
+	}
+	at := h.now()
+	h.away[p] = at
+	time.AfterFunc(reconnectWindow, func() {
+		h.forfeit(roomID, p, at)
+	})
+}
+
+// forfeit atに接続が切れたプレイヤーが再接続していなければ、そのプレイヤーの負けとして対局を終了する
+func (h *GameHandler) forfeit(roomID string, p *game.Player, at time.Time) {
+	h.Lock()
+	defer h.Unlock()
+
+	// 再接続した後にまた切れた場合は、新しく切れた時刻から数え直す
+	if since, ok := h.away[p]; !ok || !since.Equal(at) {
+		return
+	}
+	delete(h.away, p)
+	g := h.games[roomID]
+	if g == nil || g.Abandon(p.Character) != nil {
+		return
+	}
+	h.finish(roomID, g)
+}
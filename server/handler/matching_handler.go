@@ -2,12 +2,16 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"kazuki.matsumoto/reversi/build"
 	"kazuki.matsumoto/reversi/game"
 	"kazuki.matsumoto/reversi/gen/pb"
+	"kazuki.matsumoto/reversi/server/auth"
+	"kazuki.matsumoto/reversi/server/storage"
+	"log"
 	"sync"
 	"time"
 )
@@ -15,13 +19,43 @@ import (
 type MatchingHandler struct {
 	pb.UnimplementedMatchingServiceServer
 	sync.RWMutex
-	Rooms       map[int32]*game.Room
-	maxPlayerID int32
+	queue    *matchQueue        // マッチングを待っているプレイヤー
+	invites  map[string]*invite // CreateRoomで作成され、ゲストを待っている部屋。招待コードで引く
+	tables   GameTables         // 部屋の対局の準備と、対戦相手が見つからない場合にAIを着席させる先
+	registry *Registry          // 部屋のIDを発行し、作成した部屋を登録する
+	sessions *SessionStore      // マッチングしたプレイヤーに、再接続用のセッションを発行する
+	store    storage.Store      // 部屋の記録の保存先
+	profiles *Profiles          // 参加したプレイヤーのプロフィール
+	rewards  *Rewards           // マッチングしたプレイヤーの報酬の種を、対局の前に用意する
 }
 
-func NewMatchingHandler() *MatchingHandler {
+// GameTables マッチングした部屋の対局を準備する先
+type GameTables interface {
+	// PrepareRoom 部屋の設定(持ち時間など)で対局を準備する
+	PrepareRoom(room *game.Room)
+	// SeatBot 対戦相手が見つからなかったプレイヤーの部屋にAIを着席させる
+	SeatBot(roomID string, p *game.Player) error
+}
+
+const (
+	// botWaitTime AIとの対戦を許可している場合、この時間待っても対戦相手が見つからなければAIを着席させる
+	botWaitTime = 10 * time.Second
+	// botPlayerID AIのプレイヤーID。Registryが発行するIDには接頭辞が付くので重ならない
+	botPlayerID = "ai"
+	// botName AIのプレイヤー名
+	botName = "AI"
+)
+
+func NewMatchingHandler(tables GameTables, registry *Registry, sessions *SessionStore, store storage.Store, profiles *Profiles, rewards *Rewards) *MatchingHandler {
 	return &MatchingHandler{
-		Rooms: make(map[int32]*game.Room),
+		queue:    newMatchQueue(),
+		invites:  make(map[string]*invite),
+		tables:   tables,
+		registry: registry,
+		sessions: sessions,
+		store:    store,
+		profiles: profiles,
+		rewards:  rewards,
 	}
 }
 
@@ -29,90 +63,252 @@ func (h *MatchingHandler) JoinRoom(req *pb.JoinRoomRequest, stream pb.MatchingSe
 	ctx, cancel := context.WithTimeout(stream.Context(), 2*time.Minute)
 	defer cancel()
 
-	// h.roomsは複数のクライアントから同時にアクセスされるので、mutexで保護する。
-	h.Lock()
-	// Playerの新規作成
-	me := &game.Player{
-		ID: h.maxPlayerID,
-	}
-
-	// 空いている部屋を探す
-	// 作成されているh.roomsのうち、guestがnilのやつを探す
-	// roomsを全件探索するので、一つでもroomに空きがあれば必ずマッチする。
-	for _, room := range h.Rooms {
-		if room.Guest == nil {
-			me.Character = game.White
-			room.Guest = me
-			err := stream.Send(&pb.JoinRoomResponse{
-				Status: pb.JoinRoomResponse_MATCHED,
-				Room:   build.PBRoom(room),
-				Me:     build.PBPlayer(room.Guest),
-			})
-			if err != nil {
-				return err
-			}
-			h.Unlock()
-			fmt.Printf("matched room_id=%\n", room.ID)
-			return nil
-		}
+	prefs, err := joinPreferences(req)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 
-	// 部屋が空いてなかったら新規作成
-	me.Character = game.Black
-	room := &game.Room{
-		ID:   int32(len(h.Rooms)) + 1,
-		Host: me,
+	prof, err := h.register(req.GetName(), req.GetPlayerToken())
+	if err != nil {
+		return err
 	}
-	h.Rooms[room.ID] = room
+
+	// Playerの新規作成。IDはプロフィールのものを使う。色はマッチングしてから決める
+	me := &game.Player{
+		ID:   prof.ID,
+		Name: prof.Name,
+	}
+	t := newTicket(me, prof.Rating.Rating, prefs)
+
+	// h.queueは複数のクライアントから同時にアクセスされるので、mutexで保護する。
+	h.Lock()
+	h.queue.push(t)
 	h.Unlock()
+	// マッチングせずに抜ける場合は列から外す
+	defer h.leave(t)
+
+	// すでに待っているプレイヤーと希望が合えばすぐにマッチングする
+	if room := h.poll(t, req.GetAllowBot()); room != nil {
+		return h.matched(stream, room, me)
+	}
 
-	err := stream.Send(&pb.JoinRoomResponse{
-		Room:   build.PBRoom(room),
+	err = stream.Send(&pb.JoinRoomResponse{
 		Status: pb.JoinRoomResponse_WAITING,
 	})
 	if err != nil {
 		return err
 	}
 
-	// このchはdeadlineのみを監視する
-	// ここのgo routineの中で1秒おきにforを回すことで、room.Guestに値が入るまで待機することができる。
-	// go routineを使っている理由は、非ブロッキングなので負荷が少ないこと、
-	//select文でguestの参加(case <-ch)とcontextのdoneをトラッキングし、適切な処理ができること。
-	// 並行処理なのでスレッドをまるまる使用しないことなどが挙げられる。(コルーチン)
-	// 通常のforだとそのループが使われているスレッドがまるまる処理を待つことになってしまう。
-	ch := make(chan int)
-	go func(ch chan<- int) {
-		for {
-			// この前後でguestに値が入ったらstateの整合性が崩れるのでRLock
-			h.RLock()
-			guest := room.Guest
-			h.RUnlock()
-
-			if guest != nil {
-				err := stream.Send(&pb.JoinRoomResponse{
-					Status: pb.JoinRoomResponse_MATCHED,
-					Room:   build.PBRoom(room),
-					Me:     build.PBPlayer(room.Host),
-				})
-				if err != nil {
-					return
-				}
-				ch <- 0
-				break
-			}
-			time.Sleep(1 * time.Second)
-			select {
-			case <-ctx.Done():
-				return
-			default:
+	// 後から並んだプレイヤーとマッチングした場合は、t.matchedが閉じられてすぐに起きる。
+	// 待つほど広がる条件で自分から探し直すのは、許容範囲が広がる時とAIを着席させる時だけなので、
+	// 待っている間は定期的に起きることもなく、待っているプレイヤーが多くても負荷にならない
+	var check <-chan time.Time
+	reset := func() {
+		h.RLock()
+		d, ok := h.queue.nextCheck(t, req.GetAllowBot())
+		h.RUnlock()
+		check = nil
+		if ok {
+			check = time.After(d)
+		}
+	}
+	reset()
+	for {
+		select {
+		case <-t.matched:
+			// 部屋はticketを閉じる前に設定されている
+			return h.matched(stream, t.room, me)
+		case <-check:
+			if room := h.poll(t, req.GetAllowBot()); room != nil {
+				return h.matched(stream, room, me)
 			}
+			reset()
+		case <-ctx.Done():
+			return status.Errorf(codes.DeadlineExceeded, "マッチングできませんでした。")
+		}
+	}
+}
+
+// joinPreferences リクエストからマッチングの希望を取り出す
+func joinPreferences(req *pb.JoinRoomRequest) (preferences, error) {
+	prefs := preferences{
+		timeControl:    build.TimeControl(req.GetTimeControl()),
+		anyTimeControl: req.GetAnyTimeControl(),
+		color:          preferredColor(req.GetColor()),
+		variant:        build.Variant(req.GetVariant()),
+	}
+	if err := prefs.timeControl.Validate(); err != nil {
+		return prefs, err
+	}
+	if err := prefs.variant.Validate(); err != nil {
+		return prefs, err
+	}
+	return prefs, nil
+}
+
+// preferredColor 希望する色。BLACKかWHITE以外はどちらでも良いのでNone
+func preferredColor(c pb.Character) game.Character {
+	switch c {
+	case pb.Character_BLACK:
+		return game.Black
+	case pb.Character_WHITE:
+		return game.White
+	}
+	return game.None
+}
+
+// register プレイヤートークンか名前からプロフィールを決める。登録されていない名前なら登録して作成する
+func (h *MatchingHandler) register(name, token string) (*storage.Profile, error) {
+	prof, err := h.profiles.Register(name, token)
+	if errors.Is(err, ErrInvalidName) {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if errors.Is(err, auth.ErrInvalidToken) || errors.Is(err, auth.ErrExpiredToken) {
+		return nil, status.Errorf(codes.Unauthenticated, "player_token: %v", err)
+	}
+	return prof, err
+}
+
+// poll まだマッチングしていなければ対戦相手を探す。見つからないまま一定時間待っていて、AIとの対戦を許可していればAIを着席させる
+// マッチングした部屋を返す。まだであればnil
+func (h *MatchingHandler) poll(t *ticket, allowBot bool) *game.Room {
+	h.Lock()
+	defer h.Unlock()
+
+	if t.room != nil {
+		return t.room
+	}
+	if o := h.queue.partner(t); o != nil {
+		h.pair(t, o)
+	} else if allowBot && h.queue.now().Sub(t.since) >= botWaitTime {
+		h.seatBot(t)
+	}
+	return t.room
+}
+
+// pair 二人の部屋を作成し、列から外す。長く待っていた方をホストにする。ロックを取った状態で呼ぶ
+func (h *MatchingHandler) pair(a, b *ticket) {
+	if b.since.Before(a.since) {
+		a, b = b, a
+	}
+	tc, _ := a.prefs.timeControlWith(b.prefs)
+	room, err := h.openRoom(&game.Room{
+		Host:            a.player,
+		Guest:           b.player,
+		TimeControl:     tc,
+		Variant:         a.prefs.variant,
+		AllowSpectators: true,
+	})
+	if err != nil {
+		// 二人とも列に残しておき、次に探す時にまた組み合わせる
+		log.Printf("failed to open room: %v", err)
+		return
+	}
+	// 部屋を作成できてから色を決める。作成できずに列に残る二人の色は決まっていないままにする
+	h.queue.assignColors(a.player, a.prefs.color, b.player, b.prefs.color)
+	h.saveRoom(room)
+	h.tables.PrepareRoom(room)
+	a.settle(room)
+	b.settle(room)
+	h.queue.remove(a)
+	h.queue.remove(b)
+	fmt.Printf("matched room_id=%v\n", room.ID)
+}
+
+// seatBot AIをゲストとして、tの部屋を作成する。ロックを取った状態で呼ぶ
+func (h *MatchingHandler) seatBot(t *ticket) {
+	bot := &game.Player{
+		ID:   botPlayerID,
+		Bot:  true,
+		Name: botName,
+	}
+	room, err := h.openRoom(&game.Room{
+		Host:            t.player,
+		TimeControl:     t.prefs.timeControl,
+		Variant:         t.prefs.variant,
+		AllowSpectators: true,
+	})
+	if err != nil {
+		log.Printf("failed to open room: %v", err)
+		return
+	}
+	h.queue.assignColors(t.player, t.prefs.color, bot, game.None)
+	h.tables.PrepareRoom(room)
+	if err := h.tables.SeatBot(room.ID, bot); err != nil {
+		log.Printf("failed to seat bot room_id=%v: %v", room.ID, err)
+		h.registry.Close(room.ID)
+		t.player.Character = game.None
+		return
+	}
+	room.Guest = bot
+	h.saveRoom(room)
+	t.settle(room)
+	h.queue.remove(t)
+	fmt.Printf("matched with bot room_id=%v\n", room.ID)
+}
+
+// openRoom Registryで部屋にIDを発行して登録し、保存する。ロックを取った状態で呼ぶ
+// 対局は二人が揃ってからPrepareRoomで準備する。ゲストを待っている間は、始まらない対局として片付けられないようにする
+func (h *MatchingHandler) openRoom(room *game.Room) (*game.Room, error) {
+	if err := h.registry.Open(room); err != nil {
+		return nil, err
+	}
+	h.saveRoom(room)
+	return room, nil
+}
+
+// matched マッチングした部屋と、再接続用のセッションを送る
+func (h *MatchingHandler) matched(stream pb.MatchingService_JoinRoomServer, room *game.Room, me *game.Player) error {
+	res, err := h.matchedResponse(room, me)
+	if err != nil {
+		return err
+	}
+	return stream.Send(res)
+}
+
+// matchedResponse マッチングしたプレイヤーへのレスポンス。再接続用のセッションと、次に参加する時のプレイヤートークンを発行し、
+// 名前を登録したプレイヤーには、勝った時の抽選に使う種と次に使う種のハッシュを添える
+func (h *MatchingHandler) matchedResponse(room *game.Room, me *game.Player) (*pb.JoinRoomResponse, error) {
+	sess, err := h.sessions.Issue(room.ID, me)
+	if err != nil {
+		return nil, err
+	}
+	token, err := h.profiles.Token(me.ID)
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.JoinRoomResponse{
+		Status:       pb.JoinRoomResponse_MATCHED,
+		Room:         build.PBRoom(room),
+		Me:           build.PBPlayer(me),
+		SessionToken: sess.Token,
+		PlayerToken:  token,
+	}
+	// ゲストは報酬の対象外なので、種を用意しない
+	if token != "" {
+		active, pending, err := h.rewards.Seeds(me.ID)
+		if err != nil {
+			return nil, err
+		}
+		if active != nil {
+			res.SeedHash = active.Hash
 		}
-	}(ch)
+		res.NextSeedHash = pending.Hash
+	}
+	return res, nil
+}
+
+// leave マッチングしていなければ列から外す
+func (h *MatchingHandler) leave(t *ticket) {
+	h.Lock()
+	defer h.Unlock()
+
+	h.queue.remove(t)
+}
 
-	select {
-	case <-ch:
-	case <-ctx.Done():
-		return status.Errorf(codes.DeadlineExceeded, "マッチングできませんでした。")
+// saveRoom 部屋を保存する。保存に失敗してもマッチングは続ける。ロックを取った状態で呼ぶ
+func (h *MatchingHandler) saveRoom(room *game.Room) {
+	if err := h.store.SaveRoom(room); err != nil {
+		log.Printf("failed to save room room_id=%v: %v", room.ID, err)
 	}
-	return nil
 }
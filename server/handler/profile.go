@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/game/rating"
+	"kazuki.matsumoto/reversi/server/auth"
+	"kazuki.matsumoto/reversi/server/storage"
+)
+
+// ErrInvalidName プレイヤー名として使えない
+var ErrInvalidName = errors.New("invalid name")
+
+// maxNameLength プレイヤー名の最大の文字数
+const maxNameLength = 24
+
+// Profiles プレイヤーのプロフィールとレーティング。MatchingServiceで参照し、対局が終了したら更新する
+type Profiles struct {
+	sync.Mutex // 同じプレイヤーのレーティングを同時に更新しないよう、更新を直列にする
+	store      storage.ProfileStore
+	registry   *Registry    // 新しく作成するプロフィールのIDを発行する
+	signer     *auth.Signer // 名前を登録したプロフィールに、プレイヤートークンを発行する
+	now        func() time.Time
+}
+
+func NewProfiles(store storage.ProfileStore, registry *Registry, signer *auth.Signer) *Profiles {
+	return &Profiles{
+		store:    store,
+		registry: registry,
+		signer:   signer,
+		now:      time.Now,
+	}
+}
+
+// Register 参加したプレイヤーのプロフィールを返す。プレイヤートークンがあれば、トークンのプロフィールを返す。
+// なければ名前を登録して作成する。名前が空か、すでに登録されている場合はゲストとして新しく作成する。
+// 名前だけでは登録済みのプロフィールを返さないので、他のプレイヤーのレーティングや報酬を使えない
+func (p *Profiles) Register(name, token string) (*storage.Profile, error) {
+	name = strings.TrimSpace(name)
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	if token != "" {
+		return p.authenticate(name, token)
+	}
+	if name != "" {
+		prof, err := p.create(name, true)
+		if !errors.Is(err, storage.ErrNameTaken) {
+			return prof, err
+		}
+	}
+	return p.create("", false)
+}
+
+// authenticate プレイヤートークンのプロフィールを返す。名前を指定した場合は、トークンのプロフィールの名前と一致する必要がある
+func (p *Profiles) authenticate(name, token string) (*storage.Profile, error) {
+	id, err := p.signer.VerifyPlayer(token)
+	if err != nil {
+		return nil, err
+	}
+	prof, err := p.store.Profile(id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, auth.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !prof.Registered || (name != "" && name != prof.Name) {
+		return nil, auth.ErrInvalidToken
+	}
+	return prof, nil
+}
+
+// create 新しいIDでプロフィールを作成する。名前が空の場合はゲストの名前を付ける
+func (p *Profiles) create(name string, registered bool) (*storage.Profile, error) {
+	id, err := p.registry.NewPlayerID()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		// ゲストの名前はIDから作るので、他のプレイヤーと重ならない
+		name = "guest-" + strings.TrimPrefix(id, playerIDPrefix)
+	}
+	return p.store.CreateProfile(id, name, registered)
+}
+
+// Token プレイヤーのプロフィールに結びつけるトークンを発行する。ゲストには発行せず、空を返す
+func (p *Profiles) Token(playerID string) (string, error) {
+	prof, err := p.store.Profile(playerID)
+	if err != nil {
+		return "", err
+	}
+	if !prof.Registered {
+		return "", nil
+	}
+	return p.signer.SignPlayer(prof.ID)
+}
+
+func validateName(name string) error {
+	if len([]rune(name)) > maxNameLength {
+		return ErrInvalidName
+	}
+	for _, r := range name {
+		if !unicode.IsPrint(r) {
+			return ErrInvalidName
+		}
+	}
+	// ゲストに割り当てる名前とAIの名前は名乗れない
+	if strings.HasPrefix(name, "guest-") || name == botName {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+// RecordResult 終了した対局の結果で双方のレーティングを更新する。AIとの対局、中止した対局、ゲストとの対局は対象外
+func (p *Profiles) RecordResult(roomID string, g *game.Game) error {
+	if !g.Finished() || g.Termination() == game.Aborted {
+		return nil
+	}
+	black, white := g.Seated(game.Black), g.Seated(game.White)
+	if black == nil || white == nil || black.Bot || white.Bot {
+		return nil
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	bp, err := p.store.Profile(black.ID)
+	if err != nil {
+		return err
+	}
+	wp, err := p.store.Profile(white.ID)
+	if err != nil {
+		return err
+	}
+	// ゲストのプロフィールはいくらでも作れるので、使い捨てのゲストに勝ってレーティングや連勝数を稼げないようにする
+	if !bp.Registered || !wp.Registered {
+		return nil
+	}
+	// 再起動時の読み込み直しなどで同じ対局の結果が二度届いても、一度だけ反映する
+	if n := len(bp.History); n > 0 && bp.History[n-1].RoomID == roomID {
+		return nil
+	}
+
+	score := rating.Draw
+	switch g.Winner() {
+	case game.Black:
+		score = rating.Win
+	case game.White:
+		score = rating.Loss
+	}
+
+	// 双方とも対局前のレーティングで計算する
+	now := p.now()
+	bNew := rating.Update(bp.Rating, []rating.Result{{Opponent: wp.Rating, Score: score}})
+	wNew := rating.Update(wp.Rating, []rating.Result{{Opponent: bp.Rating, Score: 1 - score}})
+	applyResult(bp, wp.ID, roomID, score, bNew, now)
+	applyResult(wp, bp.ID, roomID, 1-score, wNew, now)
+
+	if err := p.store.SaveProfile(bp); err != nil {
+		return err
+	}
+	return p.store.SaveProfile(wp)
+}
+
+func applyResult(prof *storage.Profile, opponentID string, roomID string, score float64, r rating.Rating, at time.Time) {
+	switch score {
+	case rating.Win:
+		prof.Wins++
+	case rating.Loss:
+		prof.Losses++
+	default:
+		prof.Draws++
+	}
+	prof.Rating = r
+	prof.History = append(prof.History, storage.RatingChange{
+		RoomID:     roomID,
+		OpponentID: opponentID,
+		Score:      score,
+		Rating:     r,
+		PlayedAt:   at,
+	})
+}
+
+// Ranking レーティングの対象になる対局をした、名前を登録したプレイヤーを、レーティングの高い順に返す。同じ場合は先に作成された順
+func (p *Profiles) Ranking() ([]*storage.Profile, error) {
+	profiles, err := p.store.Profiles()
+	if err != nil {
+		return nil, err
+	}
+	ranked := profiles[:0]
+	for _, prof := range profiles {
+		if prof.Registered && prof.Games() > 0 {
+			ranked = append(ranked, prof)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Rating.Rating > ranked[j].Rating.Rating
+	})
+	return ranked, nil
+}
+
+// Streak レーティングの対象になった対局の、直近の連勝数
+func (p *Profiles) Streak(id string) (int, error) {
+	prof, err := p.store.Profile(id)
+	if err != nil {
+		return 0, err
+	}
+	streak := 0
+	for i := len(prof.History) - 1; i >= 0 && prof.History[i].Score == rating.Win; i-- {
+		streak++
+	}
+	return streak, nil
+}
+
+// Profile IDでプロフィールを返す
+func (p *Profiles) Profile(id string) (*storage.Profile, error) {
+	return p.store.Profile(id)
+}
+
+// reserve 保存されているプロフィールのIDを、新しく発行しないようにする。サーバーの起動時に使う
+func (p *Profiles) reserve() error {
+	profiles, err := p.store.Profiles()
+	if err != nil {
+		return err
+	}
+	for _, prof := range profiles {
+		p.registry.Reserve(prof.ID)
+	}
+	return nil
+}
+
+// ProfileByName 名前でプロフィールを返す
+func (p *Profiles) ProfileByName(name string) (*storage.Profile, error) {
+	return p.store.ProfileByName(strings.TrimSpace(name))
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"testing"
+
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/server/auth"
+	"kazuki.matsumoto/reversi/server/storage"
+)
+
+func newTestProfiles(tb testing.TB) *Profiles {
+	key, err := auth.GenerateKey()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return NewProfiles(storage.NewMemoryProfileStore(), NewRegistry(), auth.NewSigner(key))
+}
+
+// resignedGame 白が投了して黒が勝った対局
+func resignedGame(t *testing.T, black, white *storage.Profile) *game.Game {
+	t.Helper()
+	g := game.NewGame(game.None)
+	for _, p := range []*game.Player{
+		{ID: black.ID, Name: black.Name, Character: game.Black},
+		{ID: white.ID, Name: white.Name, Character: game.White},
+	} {
+		if err := g.Sit(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g.Start()
+	if err := g.Resign(game.White); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// TestRecordResultSkipsGuests ゲストとの対局ではレーティングも連勝数も変わらず、ゲストはランキングに載らない
+func TestRecordResultSkipsGuests(t *testing.T) {
+	p := newTestProfiles(t)
+	alice, err := p.Register("alice", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := p.Register("bob", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		guest, err := p.Register("", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if guest.Registered {
+			t.Fatalf("anonymous profile %v is registered", guest.Name)
+		}
+		if err := p.RecordResult("room-guest-"+guest.ID, resignedGame(t, alice, guest)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := p.Profile(alice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Games() != 0 || got.Rating != alice.Rating {
+		t.Errorf("alice after beating guests: %d games, rating %+v, want unchanged", got.Games(), got.Rating)
+	}
+	if streak, _ := p.Streak(alice.ID); streak != 0 {
+		t.Errorf("streak after beating guests = %d, want 0", streak)
+	}
+
+	if err := p.RecordResult("room-registered", resignedGame(t, alice, bob)); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := p.Profile(alice.ID); got.Wins != 1 || got.Rating.Rating <= alice.Rating.Rating {
+		t.Errorf("alice after beating bob: %d wins, rating %v", got.Wins, got.Rating.Rating)
+	}
+	if streak, _ := p.Streak(alice.ID); streak != 1 {
+		t.Errorf("streak after beating bob = %d, want 1", streak)
+	}
+
+	ranking, err := p.Ranking()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranking) != 2 || ranking[0].ID != alice.ID || ranking[1].ID != bob.ID {
+		names := make([]string, len(ranking))
+		for i, r := range ranking {
+			names[i] = r.Name
+		}
+		t.Errorf("ranking = %v, want [alice bob]", names)
+	}
+}
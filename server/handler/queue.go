@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+const (
+	// initialRatingWindow 待ち始めた時点で対戦相手として認めるレーティングの差
+	initialRatingWindow = 100.0
+	// ratingWindowGrowth ratingWindowStep待つごとに、対戦相手として認めるレーティングの差を広げる幅
+	ratingWindowGrowth = 50.0
+	ratingWindowStep   = 5 * time.Second
+	// maxRatingWindow 長く待っても、これ以上レーティングの離れた相手とは対戦しない
+	maxRatingWindow = 600.0
+)
+
+// ticket マッチングを待っているプレイヤー
+type ticket struct {
+	player *game.Player
+	rating float64
+	prefs  preferences
+	since  time.Time  // 待ち始めた時刻
+	room   *game.Room // マッチングした部屋。待っている間はnil
+	// matched マッチングしたら閉じる。待っているJoinRoomをすぐに起こすために使う
+	matched chan struct{}
+}
+
+func newTicket(p *game.Player, rating float64, prefs preferences) *ticket {
+	return &ticket{
+		player:  p,
+		rating:  rating,
+		prefs:   prefs,
+		matched: make(chan struct{}),
+	}
+}
+
+// settle マッチングした部屋を設定し、待っているJoinRoomに知らせる。ロックを取った状態で呼ぶ
+func (t *ticket) settle(room *game.Room) {
+	t.room = room
+	close(t.matched)
+}
+
+// preferences マッチングの希望
+type preferences struct {
+	timeControl    game.TimeControl
+	anyTimeControl bool           // 相手が希望する持ち時間でも良い
+	color          game.Character // 希望する色。Noneならどちらでも良い
+	variant        game.Variant
+}
+
+// matchQueue マッチングを待っているプレイヤーの列。MatchingHandlerのロックで保護する
+// 希望する色ごとに列を分け、色の希望が合わないプレイヤーは探す対象にしない
+type matchQueue struct {
+	lines map[game.Character][]*ticket // 希望する色ごとの列。それぞれ待ち始めた順
+	now   func() time.Time
+	rnd   game.Random // どちらも色を希望していない二人の色を決める
+}
+
+func newMatchQueue() *matchQueue {
+	return &matchQueue{
+		lines: make(map[game.Character][]*ticket),
+		now:   time.Now,
+		rnd:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// push 列の最後に並ぶ
+func (q *matchQueue) push(t *ticket) {
+	t.since = q.now()
+	c := t.prefs.color
+	q.lines[c] = append(q.lines[c], t)
+}
+
+// remove 列から抜ける。並んでいなければ何もしない
+func (q *matchQueue) remove(t *ticket) {
+	c := t.prefs.color
+	for i, o := range q.lines[c] {
+		if o == t {
+			q.lines[c] = append(q.lines[c][:i], q.lines[c][i+1:]...)
+			return
+		}
+	}
+}
+
+// partner tの対戦相手として、希望が合い、お互いの許容範囲に収まる中で最もレーティングの近いプレイヤーを探す。
+// 同じ差であれば長く待っている方を選ぶ。見つからなければnil
+func (q *matchQueue) partner(t *ticket) *ticket {
+	now := q.now()
+	var best *ticket
+	bestDiff := math.Inf(1)
+	for _, c := range fitColors(t.prefs.color) {
+		for _, o := range q.lines[c] {
+			if o == t || o.player.ID == t.player.ID {
+				continue
+			}
+			if _, ok := t.prefs.timeControlWith(o.prefs); !ok || t.prefs.variant != o.prefs.variant {
+				continue
+			}
+			diff := math.Abs(t.rating - o.rating)
+			window := min(ratingWindow(now.Sub(t.since)), ratingWindow(now.Sub(o.since)))
+			if diff > window {
+				continue
+			}
+			if diff < bestDiff || diff == bestDiff && o.since.Before(best.since) {
+				best, bestDiff = o, diff
+			}
+		}
+	}
+	return best
+}
+
+// fitColors cを希望するプレイヤーと、色の希望が合うプレイヤーの希望する色。二人とも同じ色を希望していなければ合う
+func fitColors(c game.Character) []game.Character {
+	switch c {
+	case game.Black:
+		return []game.Character{game.None, game.White}
+	case game.White:
+		return []game.Character{game.None, game.Black}
+	}
+	return []game.Character{game.None, game.Black, game.White}
+}
+
+// nextCheck tが次に対戦相手を探し直すまでの時間。許容範囲が広がる時か、AIを着席させる時
+// 許容範囲が広がりきっていてAIも着席させない場合は、新しく並んだプレイヤーが探してくれるのを待つだけなのでfalse
+func (q *matchQueue) nextCheck(t *ticket, allowBot bool) (time.Duration, bool) {
+	wait := q.now().Sub(t.since)
+	next, ok := time.Duration(math.MaxInt64), false
+	if ratingWindow(wait) < maxRatingWindow {
+		next, ok = ratingWindowStep-wait%ratingWindowStep, true
+	}
+	if allowBot && wait < botWaitTime {
+		next, ok = min(next, botWaitTime-wait), true
+	}
+	return next, ok
+}
+
+// ratingWindow 待った時間に応じて、対戦相手として認めるレーティングの差
+func ratingWindow(wait time.Duration) float64 {
+	steps := float64(wait / ratingWindowStep)
+	return min(initialRatingWindow+steps*ratingWindowGrowth, maxRatingWindow)
+}
+
+// timeControlWith 相手の希望と合わせた持ち時間。どちらも持ち時間を指定していれば、同じ場合だけ合う
+// どちらも相手に合わせて良い場合は、pの希望を使う
+func (p preferences) timeControlWith(o preferences) (game.TimeControl, bool) {
+	switch {
+	case !p.anyTimeControl && !o.anyTimeControl:
+		return p.timeControl, p.timeControl == o.timeControl
+	case !o.anyTimeControl:
+		return o.timeControl, true
+	}
+	return p.timeControl, true
+}
+
+// assignColors 二人の色を決める。caとcbはそれぞれが希望する色で、Noneならどちらでも良い
+// 希望があればそれに従い、どちらも希望がなければq.rndで決める。ロックを取った状態で呼ぶ
+func (q *matchQueue) assignColors(a *game.Player, ca game.Character, b *game.Player, cb game.Character) {
+	switch {
+	case ca != game.None:
+		a.Character = ca
+	case cb != game.None:
+		a.Character = game.OpponentCharacter(cb)
+	case q.rnd.Intn(2) == 0:
+		a.Character = game.Black
+	default:
+		a.Character = game.White
+	}
+	b.Character = game.OpponentCharacter(a.Character)
+}
@@ -0,0 +1,430 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/game/rating"
+	"kazuki.matsumoto/reversi/gen/pb"
+	"kazuki.matsumoto/reversi/server/auth"
+	"kazuki.matsumoto/reversi/server/reward"
+	"kazuki.matsumoto/reversi/server/storage"
+)
+
+const (
+	// benchWaiters ベンチマークの間、マッチングを待たせておくプレイヤーの数
+	benchWaiters = 1000
+	// pollInterval 以前のJoinRoomが、マッチングしたかを確かめていた間隔
+	pollInterval = time.Second
+)
+
+// fixedRandom 常に同じ値を返す乱数
+type fixedRandom int
+
+func (f fixedRandom) Intn(n int) int {
+	return int(f) % n
+}
+
+func TestAssignColors(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		ca, cb game.Character
+		rnd    int
+		want   game.Character // aの色
+	}{
+		{"a prefers white", game.White, game.None, 0, game.White},
+		{"b prefers white", game.None, game.White, 0, game.Black},
+		{"both prefer", game.Black, game.White, 1, game.Black},
+		{"no preference, random 0", game.None, game.None, 0, game.Black},
+		{"no preference, random 1", game.None, game.None, 1, game.White},
+	} {
+		q := newMatchQueue()
+		q.rnd = fixedRandom(c.rnd)
+		a, b := &game.Player{ID: "a"}, &game.Player{ID: "b"}
+		q.assignColors(a, c.ca, b, c.cb)
+		if a.Character != c.want || b.Character != game.OpponentCharacter(c.want) {
+			t.Errorf("%v: colors = %v, %v, want %v, %v", c.name, a.Character, b.Character, c.want, game.OpponentCharacter(c.want))
+		}
+	}
+}
+
+// testQueue 進めた分だけ時刻が進む列
+func testQueue() (*matchQueue, func(time.Duration)) {
+	now := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	q := newMatchQueue()
+	q.now = func() time.Time { return now }
+	return q, func(d time.Duration) { now = now.Add(d) }
+}
+
+// testTicket 列に並ぶプレイヤー。prefsで色を指定していなければ、どちらの色でも良い
+func testTicket(id string, rating float64, prefs preferences) *ticket {
+	if prefs.color == game.Empty {
+		prefs.color = game.None
+	}
+	return newTicket(&game.Player{ID: id, Name: id}, rating, prefs)
+}
+
+// ticketID 並んでいるプレイヤーのID。いなければ"nil"
+func ticketID(t *ticket) string {
+	if t == nil {
+		return "nil"
+	}
+	return t.player.ID
+}
+
+func TestRatingWindow(t *testing.T) {
+	for _, c := range []struct {
+		wait time.Duration
+		want float64
+	}{
+		{0, initialRatingWindow},
+		{ratingWindowStep - time.Nanosecond, initialRatingWindow},
+		{ratingWindowStep, initialRatingWindow + ratingWindowGrowth},
+		{3 * ratingWindowStep, initialRatingWindow + 3*ratingWindowGrowth},
+		{time.Hour, maxRatingWindow},
+	} {
+		if got := ratingWindow(c.wait); got != c.want {
+			t.Errorf("ratingWindow(%v) = %v, want %v", c.wait, got, c.want)
+		}
+	}
+}
+
+// TestPartnerRatingWindow レーティングの離れたプレイヤーは、二人とも許容範囲が広がるまで待ってからマッチングする
+func TestPartnerRatingWindow(t *testing.T) {
+	q, advance := testQueue()
+	a := testTicket("a", 1500, preferences{})
+	b := testTicket("b", 1700, preferences{})
+	q.push(a)
+	q.push(b)
+	if o := q.partner(a); o != nil {
+		t.Fatalf("partner of a = %v right after joining, want nil", ticketID(o))
+	}
+	advance(ratingWindowStep)
+	if o := q.partner(a); o != nil {
+		t.Fatalf("partner of a = %v after %v, want nil", ticketID(o), ratingWindowStep)
+	}
+	// 差の200は、2段階広がった時点で許容範囲に入る
+	advance(ratingWindowStep)
+	if o := q.partner(a); o != b {
+		t.Fatalf("partner of a = %v after %v, want b", ticketID(o), 2*ratingWindowStep)
+	}
+	if o := q.partner(b); o != a {
+		t.Fatalf("partner of b = %v after %v, want a", ticketID(o), 2*ratingWindowStep)
+	}
+
+	// 長く待っていても、後から並んだ相手の許容範囲が広がるまではマッチングしない
+	c := testTicket("c", 1900, preferences{})
+	q.push(c)
+	if o := q.partner(b); o != a {
+		t.Errorf("partner of b = %v, want a", ticketID(o))
+	}
+	advance(ratingWindowStep)
+	if o := q.partner(c); o != nil {
+		t.Errorf("partner of c = %v after %v, want nil", ticketID(o), ratingWindowStep)
+	}
+	advance(ratingWindowStep)
+	if o := q.partner(c); o != b {
+		t.Errorf("partner of c = %v after %v, want b", ticketID(o), 2*ratingWindowStep)
+	}
+
+	// 許容範囲の上限より離れていれば、いつまで待ってもマッチングしない
+	q.remove(c)
+	far := testTicket("far", 1700+maxRatingWindow+1, preferences{})
+	q.push(far)
+	advance(time.Hour)
+	if o := q.partner(far); o != nil {
+		t.Errorf("partner of far = %v, want nil beyond the maximum window", ticketID(o))
+	}
+}
+
+// TestPartnerPreferences 希望が合わない相手とは、許容範囲が広がりきってもマッチングしない
+func TestPartnerPreferences(t *testing.T) {
+	blitz := game.TimeControl{Kind: game.Fischer, Main: 3 * time.Minute, Increment: 2 * time.Second}
+	rapid := game.TimeControl{Kind: game.Absolute, Main: 10 * time.Minute}
+	for _, c := range []struct {
+		name string
+		a, b preferences
+		want bool
+	}{
+		{"same time control", preferences{timeControl: blitz}, preferences{timeControl: blitz}, true},
+		{"different time control", preferences{timeControl: blitz}, preferences{timeControl: rapid}, false},
+		{"untimed and timed", preferences{}, preferences{timeControl: rapid}, false},
+		{"a accepts any time control", preferences{timeControl: blitz, anyTimeControl: true}, preferences{timeControl: rapid}, true},
+		{"b accepts any time control", preferences{timeControl: blitz}, preferences{timeControl: rapid, anyTimeControl: true}, true},
+		{"different variant", preferences{variant: game.Standard}, preferences{variant: game.UnknownVariant}, false},
+		{"both prefer black", preferences{color: game.Black}, preferences{color: game.Black}, false},
+		{"opposite colors", preferences{color: game.Black}, preferences{color: game.White}, true},
+		{"one prefers a color", preferences{color: game.White}, preferences{}, true},
+	} {
+		q, advance := testQueue()
+		a := testTicket("a", 1500, c.a)
+		b := testTicket("b", 1500, c.b)
+		q.push(a)
+		q.push(b)
+		advance(time.Hour)
+		if got := q.partner(a) == b; got != c.want {
+			t.Errorf("%v: a matched b = %v, want %v", c.name, got, c.want)
+		}
+		if got := q.partner(b) == a; got != c.want {
+			t.Errorf("%v: b matched a = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+// TestPartnerPrefersClosestRating 許容範囲に収まる中で最もレーティングの近い相手を選び、同じ差なら長く待っている方を選ぶ
+func TestPartnerPrefersClosestRating(t *testing.T) {
+	q, advance := testQueue()
+	me := testTicket("me", 1500, preferences{})
+	far := testTicket("far", 1580, preferences{})
+	newer := testTicket("newer", 1540, preferences{})
+	older := testTicket("older", 1460, preferences{})
+	self := testTicket("me", 1500, preferences{color: game.Black})
+	q.push(far)
+	q.push(older)
+	advance(time.Second)
+	q.push(newer)
+	q.push(self)
+	q.push(me)
+	if o := q.partner(me); o != older {
+		t.Errorf("partner = %v, want older", ticketID(o))
+	}
+	q.remove(older)
+	if o := q.partner(me); o != newer {
+		t.Errorf("partner = %v, want newer", ticketID(o))
+	}
+}
+
+func TestNextCheck(t *testing.T) {
+	for _, c := range []struct {
+		wait     time.Duration
+		allowBot bool
+		want     time.Duration
+		ok       bool
+	}{
+		{0, false, ratingWindowStep, true},
+		{ratingWindowStep + time.Second, false, ratingWindowStep - time.Second, true},
+		{0, true, ratingWindowStep, true},
+		{botWaitTime - time.Second, true, time.Second, true},
+		// 許容範囲が広がりきった後は、新しく並んだプレイヤーを待つだけ
+		{time.Hour, false, 0, false},
+		{time.Hour, true, 0, false},
+	} {
+		q, advance := testQueue()
+		tk := testTicket("a", 1500, preferences{})
+		q.push(tk)
+		advance(c.wait)
+		got, ok := q.nextCheck(tk, c.allowBot)
+		if ok != c.ok || ok && got != c.want {
+			t.Errorf("nextCheck after %v (allowBot=%v) = %v, %v, want %v, %v", c.wait, c.allowBot, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+// TestPollSeatsBot 対戦相手が見つからないままbotWaitTime待つと、AIとの対戦を許可していればAIを着席させる
+func TestPollSeatsBot(t *testing.T) {
+	h := newTestMatchingHandler(t)
+	now := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	h.queue.now = func() time.Time { return now }
+	human := testTicket("human", 1500, preferences{})
+	noBot := testTicket("nobot", 3000, preferences{})
+	h.Lock()
+	h.queue.push(human)
+	h.queue.push(noBot)
+	h.Unlock()
+
+	now = now.Add(botWaitTime - time.Nanosecond)
+	if room := h.poll(human, true); room != nil {
+		t.Fatalf("seated a bot after %v, want to wait %v", botWaitTime-time.Nanosecond, botWaitTime)
+	}
+	now = now.Add(time.Nanosecond)
+	room := h.poll(human, true)
+	if room == nil {
+		t.Fatalf("no room after %v", botWaitTime)
+	}
+	if room.Host != human.player || room.Guest == nil || !room.Guest.Bot {
+		t.Errorf("room = host %v, guest %v, want the bot as the guest", room.Host, room.Guest)
+	}
+	if human.player.Character == game.None || room.Guest.Character != game.OpponentCharacter(human.player.Character) {
+		t.Errorf("colors = %v, %v, want opposite colors", human.player.Character, room.Guest.Character)
+	}
+	for _, o := range h.queue.lines[game.None] {
+		if o == human {
+			t.Errorf("%v is still queued after the bot was seated", o.player.ID)
+		}
+	}
+
+	// AIとの対戦を許可していなければ、いつまでも人間の相手を待つ
+	now = now.Add(time.Hour)
+	if room := h.poll(noBot, false); room != nil {
+		t.Errorf("room %v opened without allowing a bot", room.ID)
+	}
+}
+
+// BenchmarkJoinRoomNotify 待っているプレイヤーが、後から並んだプレイヤーとマッチングしたことを知るまでの時間。
+// 待っている間はt.matchedが閉じられるまで眠っているので、待っているプレイヤーが多くてもCPUを使わない
+func BenchmarkJoinRoomNotify(b *testing.B) {
+	h := newTestMatchingHandler(b)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 待ち始めた順にマッチングするので、先頭のプレイヤーが次に知らされる
+	var hosts []*testMatchingStream
+	wait := func() {
+		host := newTestMatchingStream(ctx)
+		go h.JoinRoom(&pb.JoinRoomRequest{Color: pb.Character_WHITE}, host)
+		host.wait(pb.JoinRoomResponse_WAITING)
+		hosts = append(hosts, host)
+	}
+	for i := 0; i < benchWaiters; i++ {
+		wait()
+	}
+
+	cpu := cpuSeconds()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		go h.JoinRoom(&pb.JoinRoomRequest{Color: pb.Character_BLACK}, newTestMatchingStream(ctx))
+		hosts[0].wait(pb.JoinRoomResponse_MATCHED)
+
+		b.StopTimer()
+		hosts = hosts[1:]
+		wait()
+		b.StartTimer()
+	}
+	b.StopTimer()
+	b.ReportMetric((cpuSeconds()-cpu)*1e9/float64(b.N), "cpu-ns/op")
+}
+
+// BenchmarkJoinRoomPolling BenchmarkJoinRoomNotifyと同じマッチングを、以前のJoinRoomと同じく
+// 待っているプレイヤーがpollIntervalおきにロックを取って確かめる方式で知る場合。比較のために残す
+func BenchmarkJoinRoomPolling(b *testing.B) {
+	h := newTestMatchingHandler(b)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	var hosts []chan struct{}
+	n := 0
+	wait := func() {
+		t := newTicket(&game.Player{ID: fmt.Sprintf("polling-%d", n)}, rating.Default().Rating, preferences{color: game.White})
+		n++
+		// 実際には並んだ時刻がばらばらなので、確かめる時刻もpollIntervalの間にランダムにばらす
+		offset := time.Duration(rand.Int63n(int64(pollInterval)))
+		h.Lock()
+		h.queue.push(t)
+		h.Unlock()
+		notified := make(chan struct{})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			poll(ctx, h, t, offset, notified)
+		}()
+		hosts = append(hosts, notified)
+	}
+	for i := 0; i < benchWaiters; i++ {
+		wait()
+	}
+
+	cpu := cpuSeconds()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		go h.JoinRoom(&pb.JoinRoomRequest{Color: pb.Character_BLACK}, newTestMatchingStream(ctx))
+		<-hosts[0]
+
+		b.StopTimer()
+		hosts = hosts[1:]
+		wait()
+		b.StartTimer()
+	}
+	b.StopTimer()
+	b.ReportMetric((cpuSeconds()-cpu)*1e9/float64(b.N), "cpu-ns/op")
+	cancel()
+}
+
+// poll 以前のJoinRoomの待ち方。offset待ってから確かめ始める。マッチングしたらnotifiedを閉じる
+func poll(ctx context.Context, h *MatchingHandler, t *ticket, offset time.Duration, notified chan<- struct{}) {
+	select {
+	case <-time.After(offset):
+	case <-ctx.Done():
+		return
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		h.RLock()
+		room := t.room
+		h.RUnlock()
+		if room != nil {
+			close(notified)
+			return
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// cpuSeconds このプロセスがGoのコードの実行に使ったCPU時間(秒)。値はGCの時に更新されるので、GCしてから読む
+func cpuSeconds() float64 {
+	runtime.GC()
+	s := []metrics.Sample{{Name: "/cpu/classes/user:cpu-seconds"}}
+	metrics.Read(s)
+	return s[0].Value.Float64()
+}
+
+func newTestMatchingHandler(tb testing.TB) *MatchingHandler {
+	key, err := auth.GenerateKey()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	store := storage.NewMemoryStore()
+	registry := NewRegistry()
+	signer := auth.NewSigner(key)
+	profiles := NewProfiles(storage.NewMemoryProfileStore(), registry, signer)
+	rewards := NewRewards(storage.NewMemoryRewardStore(), storage.NewMemorySeedStore(), reward.Default(), profiles)
+	games := NewGameHandler(registry, store, profiles, rewards)
+	return NewMatchingHandler(games, registry, NewSessionStore(store, signer), store, profiles, rewards)
+}
+
+// testMatchingStream JoinRoomのレスポンスを受け取るstream
+type testMatchingStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.JoinRoomResponse
+}
+
+func newTestMatchingStream(ctx context.Context) *testMatchingStream {
+	return &testMatchingStream{
+		ctx:  ctx,
+		sent: make(chan *pb.JoinRoomResponse, 2),
+	}
+}
+
+func (s *testMatchingStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testMatchingStream) Send(res *pb.JoinRoomResponse) error {
+	s.sent <- res
+	return nil
+}
+
+// wait statusのレスポンスを受け取るまで待つ
+func (s *testMatchingStream) wait(status pb.JoinRoomResponse_Status) *pb.JoinRoomResponse {
+	for res := range s.sent {
+		if res.GetStatus() == status {
+			return res
+		}
+	}
+	return nil
+}
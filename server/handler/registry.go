@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+const (
+	roomIDPrefix   = "room-"
+	playerIDPrefix = "player-"
+	// idLength IDのうち乱数で作る部分の文字数。31種類の文字なので約59ビットになり、推測できない
+	idLength = 12
+	// idAlphabet IDに使う文字。招待コードと同じく、紛らわしい文字を除く
+	idAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// ErrRoomNotFound Registryに登録されていない部屋
+var ErrRoomNotFound = errors.New("room not found")
+
+// Registry 部屋とプレイヤーのIDを発行し、進行中の部屋を管理する。
+// MatchingServiceとGameServiceで共有し、部屋があるかどうかはどちらもここで確かめる
+type Registry struct {
+	sync.RWMutex
+	rooms map[string]*game.Room // 進行中の部屋
+	used  map[string]bool       // 発行したID。部屋を片付けた後や再起動後も、保存されている記録と重ならないようにする
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		rooms: make(map[string]*game.Room),
+		used:  make(map[string]bool),
+	}
+}
+
+// NewPlayerID プレイヤーのIDを発行する
+func (r *Registry) NewPlayerID() (string, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.issue(playerIDPrefix)
+}
+
+// Open 部屋にIDを発行して登録する
+func (r *Registry) Open(room *game.Room) error {
+	r.Lock()
+	defer r.Unlock()
+
+	id, err := r.issue(roomIDPrefix)
+	if err != nil {
+		return err
+	}
+	room.ID = id
+	r.rooms[id] = room
+	return nil
+}
+
+// Restore 保存されていた部屋を登録し直す。サーバーの起動時に使う
+func (r *Registry) Restore(room *game.Room) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.used[room.ID] = true
+	r.rooms[room.ID] = room
+}
+
+// Reserve 保存されている記録やプロフィールのIDを、新しく発行しないようにする。サーバーの起動時に使う
+func (r *Registry) Reserve(id string) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.used[id] = true
+}
+
+// Room 進行中の部屋を返す
+func (r *Registry) Room(id string) (*game.Room, bool) {
+	r.RLock()
+	defer r.RUnlock()
+
+	room, ok := r.rooms[id]
+	return room, ok
+}
+
+// Close 部屋を外す。IDは再び発行しない
+func (r *Registry) Close(ids ...string) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, id := range ids {
+		delete(r.rooms, id)
+	}
+}
+
+// issue 発行していないIDを作る。ロックを取った状態で呼ぶ
+func (r *Registry) issue(prefix string) (string, error) {
+	// 発行したIDと重なったら作り直す。IDの種類は十分に多いので、すぐに見つかる
+	for i := 0; i < 10; i++ {
+		var b strings.Builder
+		b.WriteString(prefix)
+		for j := 0; j < idLength; j++ {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(idAlphabet))))
+			if err != nil {
+				return "", err
+			}
+			b.WriteByte(idAlphabet[n.Int64()])
+		}
+		if id := b.String(); !r.used[id] {
+			r.used[id] = true
+			return id, nil
+		}
+	}
+	return "", errors.New("failed to generate id")
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+	"kazuki.matsumoto/reversi/server/storage"
+)
+
+// Restore 保存されている記録から、終了していない対局を読み込み直す。サーバーの起動時に、接続を受け付ける前に呼ぶ。
+// 開始前の部屋は、待っていたクライアントのマッチングが切れているので中止として記録する
+func Restore(store storage.Store, registry *Registry, h *GameHandler, sessions *SessionStore) error {
+	// 新しく発行するIDが、保存されているプロフィールや記録と重ならないようにする
+	if err := h.profiles.reserve(); err != nil {
+		return err
+	}
+	records, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		registry.Reserve(r.Room.ID)
+		if r.Finished() {
+			continue
+		}
+		if !r.Started {
+			if err := abortRecord(store, r); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := h.restore(r); err != nil {
+			// 1局の記録が壊れていても他の対局は再開する
+			log.Printf("failed to restore game room_id=%v: %v", r.Room.ID, err)
+			continue
+		}
+
+		room := r.Room
+		registry.Restore(&room)
+		for c, token := range r.Tokens {
+			if p := h.games[room.ID].Seated(c); p != nil {
+				sessions.Restore(token, room.ID, p)
+			}
+		}
+		fmt.Printf("restored room_id=%v ply=%v\n", room.ID, len(r.Moves))
+	}
+	return nil
+}
+
+// restore 記録の棋譜を打ち直して対局を再開する
+func (h *GameHandler) restore(r *storage.Record) error {
+	h.Lock()
+	defer h.Unlock()
+
+	room := r.Room
+	if room.Host == nil || room.Guest == nil {
+		return fmt.Errorf("room_id=%v: players are missing", room.ID)
+	}
+	g := game.NewGameWithBoard(game.None, game.NewBitBoard())
+	for _, p := range []*game.Player{room.Host, room.Guest} {
+		if err := g.Sit(p); err != nil {
+			return err
+		}
+	}
+	for _, ply := range r.Moves {
+		var err error
+		if ply.Pass {
+			err = g.Pass(ply.Character)
+		} else {
+			_, err = g.Move(ply.X, ply.Y, ply.Character)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	g.Start()
+	h.games[room.ID] = g
+	h.client[room.ID] = make([]pb.GameService_PlayServer, 0, RoomJoinNum)
+	h.touched[room.ID] = h.now()
+
+	// 終局の直後に止まって結果が保存されていなければ、結果だけ保存する
+	if g.Finished() {
+		h.save(room.ID, g)
+		h.settle(room.ID, g)
+		return nil
+	}
+
+	if room.Guest.Bot {
+		h.bots[room.ID] = room.Guest
+	}
+	h.configure(&room)
+	// 持ち時間は最後に保存した時点から再開する。サーバーが止まっていた間と、手番のプレイヤーが再接続するまでの時間は使わなかったものとする
+	// 時計はresumeで手番のプレイヤーが戻った時に動かす。AIの手番ならすぐに打つので、ここで動かす
+	if clock, ok := h.clocks[room.ID]; ok {
+		for c, d := range r.Clocks {
+			clock.SetRemaining(c, d)
+		}
+		if room.Guest.Bot && g.Turn() == room.Guest.Character {
+			h.startClock(room.ID, g)
+		} else {
+			go h.watchClock(room.ID, g, clock)
+		}
+	}
+	h.triggerBot(room.ID, g)
+	// 再起動でstreamは切れているので、接続が切れたのと同じく再接続を待つ
+	for _, p := range []*game.Player{room.Host, room.Guest} {
+		h.leave(room.ID, g, p)
+	}
+	return nil
+}
+
+// abortRecord 開始していない部屋の記録を中止として保存する
+func abortRecord(store storage.Store, r *storage.Record) error {
+	g := game.NewGame(game.None)
+	if err := g.Abort(game.Black); err != nil {
+		return err
+	}
+	return store.SaveGame(r.Room.ID, g, nil)
+}
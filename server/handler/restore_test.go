@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+	"kazuki.matsumoto/reversi/server/storage"
+)
+
+// TestRestoreStartsClockOnResume 再開した対局の時計は、手番のプレイヤーが戻るまで動かない
+func TestRestoreStartsClockOnResume(t *testing.T) {
+	m := newTestMatchingHandler(t)
+	h := m.tables.(*GameHandler)
+	black := &game.Player{ID: "black", Name: "black", Character: game.Black}
+	white := &game.Player{ID: "white", Name: "white", Character: game.White}
+	r := &storage.Record{
+		Room: game.Room{
+			ID:          "room-restore",
+			Host:        black,
+			Guest:       white,
+			TimeControl: game.TimeControl{Kind: game.Absolute, Main: time.Minute},
+		},
+		Started: true,
+		Moves:   []game.Ply{{Character: game.Black, X: 6, Y: 5}},
+		Clocks:  map[game.Character]time.Duration{game.Black: 50 * time.Second, game.White: 40 * time.Second},
+	}
+	if err := h.restore(r); err != nil {
+		t.Fatal(err)
+	}
+	clock := h.clocks[r.Room.ID]
+	if c := clock.Running(); c != game.None {
+		t.Fatalf("clock running for %v after restore, want stopped", c)
+	}
+
+	// 手番ではない黒が戻っても、時計は止めたまま
+	if err := h.resume(&testPlayStream{}, r.Room.ID, black); err != nil {
+		t.Fatal(err)
+	}
+	if c := clock.Running(); c != game.None {
+		t.Fatalf("clock running for %v after black resumed, want stopped", c)
+	}
+
+	if err := h.resume(&testPlayStream{}, r.Room.ID, white); err != nil {
+		t.Fatal(err)
+	}
+	if c := clock.Running(); c != game.White {
+		t.Fatalf("clock running for %v after white resumed, want %v", c, game.White)
+	}
+	if left, _ := clock.Remaining(game.White); left > 40*time.Second || left < 39*time.Second {
+		t.Errorf("white has %v left, want about 40s", left)
+	}
+}
+
+// testPlayStream 送られたレスポンスを記録するPlayのstream。GameHandlerのロックを取った状態で送られる
+type testPlayStream struct {
+	grpc.ServerStream
+	sent []*pb.PlayResponse
+}
+
+func (s *testPlayStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *testPlayStream) Send(res *pb.PlayResponse) error {
+	s.sent = append(s.sent, res)
+	return nil
+}
+
+// last 最後に送られたレスポンス
+func (s *testPlayStream) last() *pb.PlayResponse {
+	if len(s.sent) == 0 {
+		return nil
+	}
+	return s.sent[len(s.sent)-1]
+}
+
+func (s *testPlayStream) Recv() (*pb.PlayRequest, error) {
+	return nil, io.EOF
+}
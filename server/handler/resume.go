@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// resume 通信が切れたクライアントを、マッチング時に発行したトークンのプレイヤーの席に戻し、対局の現在の状態を送る
+func (h *GameHandler) resume(stream pb.GameService_PlayServer, roomID string, req *game.Player) error {
+	h.Lock()
+	defer h.Unlock()
+
+	g := h.games[roomID]
+	if g == nil {
+		return reject(stream, ErrRoomNotFound)
+	}
+	p := g.Seated(req.Character)
+	if p == nil || p.ID != req.ID {
+		return reject(stream, errNotSeated)
+	}
+
+	// 切断を検知する前に再接続された場合は、古いstreamがまだ残っているので外す
+	for s, st := range h.players {
+		if s != stream && st.roomID == roomID && st.player == p {
+			h.detach(s)
+		}
+	}
+	h.detach(stream)
+	delete(h.away, p)
+	h.players[stream] = &seat{roomID: roomID, player: p}
+	h.client[roomID] = append(h.client[roomID], stream)
+
+	// 再起動後に止めてある時計は、手番のプレイヤーが戻ってから動かす
+	if clock, ok := h.clocks[roomID]; ok && g.Started() && !g.Finished() && clock.Running() == game.None && g.Turn() == p.Character {
+		clock.Run(p.Character)
+	}
+
+	return stream.Send(h.snapshot(roomID, g, p))
+}
@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"errors"
+	"sync"
+	"time"
+	"unicode"
+
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/server/reward"
+	"kazuki.matsumoto/reversi/server/storage"
+)
+
+var (
+	// ErrInvalidClientSeed プレイヤーが決める種として使えない
+	ErrInvalidClientSeed = errors.New("invalid client seed")
+	// ErrSeedNotCommitted プレイヤーの種のハッシュを対局前に公開していないので、抽選できない
+	ErrSeedNotCommitted = errors.New("seed not committed")
+)
+
+// maxClientSeedLength プレイヤーが決める種の最大の文字数
+const maxClientSeedLength = 64
+
+// Rewards 対局に勝ったプレイヤーへの報酬。抽選はサーバーで行い、付与した報酬は全て記録する。
+// 抽選の乱数はプレイヤーごとの種から決まり、種を明かした後はプレイヤーが抽選をやり直して確かめられる
+type Rewards struct {
+	sync.Mutex // 同じプレイヤーの天井までの回数や種の番号を同時に数えないよう、付与を直列にする
+	store      storage.RewardStore
+	seeds      storage.SeedStore
+	table      *reward.Table
+	profiles   *Profiles // 連勝数でプールを選ぶ
+	now        func() time.Time
+}
+
+func NewRewards(store storage.RewardStore, seeds storage.SeedStore, table *reward.Table, profiles *Profiles) *Rewards {
+	return &Rewards{
+		store:    store,
+		seeds:    seeds,
+		table:    table,
+		profiles: profiles,
+		now:      time.Now,
+	}
+}
+
+// Item 所持しているカードと枚数
+type Item struct {
+	CardID string
+	Count  int
+}
+
+// SetTable 抽選表を差し替える。次に付与する報酬から使う
+func (r *Rewards) SetTable(table *reward.Table) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.table = table
+}
+
+// Table 今の抽選表。公開する提供割合
+func (r *Rewards) Table() *reward.Table {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.table
+}
+
+// Grant 双方置ける場所がなくなるまで打った対局に勝ったプレイヤーに、報酬を抽選して付与する。
+// 投了、時間切れ、切断での勝ち、引き分け、中止、AIの勝ち、ゲストの勝ちは対象外でnilを返す。
+// 同じ対局ですでに付与していれば、抽選し直さずにその報酬を返す。
+// 抽選にはマッチングの時にハッシュを公開した種を使い、公開した種がなければ抽選しない
+func (r *Rewards) Grant(roomID string, g *game.Game) (*storage.Grant, error) {
+	// 1手打って投了するような対局を繰り返して報酬を集められないよう、終局まで打った対局だけを対象にする
+	if g.Termination() != game.Completed {
+		return nil, nil
+	}
+	winner := g.Seated(g.Winner())
+	if winner == nil || winner.Bot {
+		return nil, nil
+	}
+	// 名前を登録していないプロフィールはいくらでも作れるので、プレイヤートークンを持つプロフィールにだけ付与する
+	prof, err := r.profiles.Profile(winner.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !prof.Registered {
+		return nil, nil
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	// 再起動時の読み込み直しなどで同じ対局の結果が二度届いても、一度だけ抽選する
+	grant, err := r.store.Granted(roomID, winner.ID)
+	if err == nil {
+		return grant, nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+
+	cond, err := r.condition(g, winner)
+	if err != nil {
+		return nil, err
+	}
+	pool := r.table.Pool(cond)
+	grants, err := r.store.Grants(winner.ID)
+	if err != nil {
+		return nil, err
+	}
+	// 抽選の結果を見てから種を選べないよう、ここでは種を作らない
+	seed, err := r.seeds.ActiveSeed(winner.ID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, ErrSeedNotCommitted
+	} else if err != nil {
+		return nil, err
+	}
+	item, pity := pool.Draw(missed(grants, pool), game.NewFairRandom(seed.ServerSeed, seed.ClientSeed, seed.Nonce))
+
+	grant = &storage.Grant{
+		RoomID:    roomID,
+		PlayerID:  winner.ID,
+		CardID:    item.CardID,
+		Pool:      pool.Name,
+		Rarity:    item.Rarity,
+		Pity:      pity,
+		SeedHash:  seed.Hash,
+		Nonce:     seed.Nonce,
+		GrantedAt: r.now(),
+	}
+	// 同じ番号で二度抽選しないよう、報酬を記録する前に番号を進める
+	seed.Nonce++
+	if err := r.seeds.SaveSeed(seed); err != nil {
+		return nil, err
+	}
+	if err := r.store.Grant(grant); errors.Is(err, storage.ErrAlreadyGranted) {
+		return r.store.Granted(roomID, winner.ID)
+	} else if err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+// condition 勝ったプレイヤーがどのプールで抽選するかを決める条件
+func (r *Rewards) condition(g *game.Game, winner *game.Player) (reward.Condition, error) {
+	loser := g.Seated(g.Loser())
+	if loser != nil && loser.Bot {
+		return reward.Condition{Mode: reward.ModeBot}, nil
+	}
+	streak, err := r.profiles.Streak(winner.ID)
+	if err != nil {
+		return reward.Condition{}, err
+	}
+	return reward.Condition{Mode: reward.ModeRated, Streak: streak}, nil
+}
+
+// missed プールで最高レアリティが出ずに続いた抽選の回数。付与した報酬の履歴から数える
+func missed(grants []*storage.Grant, pool *reward.Pool) int {
+	top := pool.Top()
+	n := 0
+	for i := len(grants) - 1; i >= 0; i-- {
+		if grants[i].Pool != pool.Name {
+			continue
+		}
+		if grants[i].Rarity >= top {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// Seeds プレイヤーの抽選に使っている種と、次に入れ替える時に使う種を返す。サーバーの種は呼び出し元で公開しない。
+// 抽選に使っている種は、まだ入れ替えたことがなければnil。次に使う種はなければ作る。
+// マッチングの時に呼び、対局前に両方のハッシュを公開しておく
+func (r *Rewards) Seeds(playerID string) (active *storage.Seed, pending *storage.Seed, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	active, err = r.seeds.ActiveSeed(playerID)
+	if errors.Is(err, storage.ErrNotFound) {
+		active = nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	pending, err = r.pendingSeed(playerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return active, pending, nil
+}
+
+// RotateSeed 今の種を明かし、ハッシュを公開済みの次の種にプレイヤーが決めた種を組み合わせて、抽選に使い始める。
+// サーバーの種はプレイヤーの種を受け取る前に決まっているので、プレイヤーの種に合わせて選び直せない。
+// 明かした種(まだ入れ替えたことがなければnil)、使い始めた種、その次に使う種を返す
+func (r *Rewards) RotateSeed(playerID, clientSeed string) (revealed, active, pending *storage.Seed, err error) {
+	// 前の種を引き継ぐと、次の種を作る前からプレイヤーの種がわかってしまうので、毎回決めてもらう
+	if clientSeed == "" {
+		return nil, nil, nil, ErrInvalidClientSeed
+	}
+	if err := validateClientSeed(clientSeed); err != nil {
+		return nil, nil, nil, err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	// ハッシュを公開していない種は使えない
+	active, err = r.seeds.PendingSeed(playerID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, nil, ErrSeedNotCommitted
+	} else if err != nil {
+		return nil, nil, nil, err
+	}
+
+	revealed, err = r.seeds.ActiveSeed(playerID)
+	if errors.Is(err, storage.ErrNotFound) {
+		revealed = nil
+	} else if err != nil {
+		return nil, nil, nil, err
+	} else {
+		now := r.now()
+		revealed.RevealedAt = &now
+		if err := r.seeds.SaveSeed(revealed); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	active.Pending = false
+	active.ClientSeed = clientSeed
+	if err := r.seeds.SaveSeed(active); err != nil {
+		return nil, nil, nil, err
+	}
+	pending, err = r.newPendingSeed(playerID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return revealed, active, pending, nil
+}
+
+// SeedByHash ハッシュで種を返す。報酬の履歴に、明かした種を添えるのに使う
+func (r *Rewards) SeedByHash(hash string) (*storage.Seed, error) {
+	return r.seeds.Seed(hash)
+}
+
+// pendingSeed プレイヤーの次に使う種を返す。なければ作る。ロックを取った状態で呼ぶ
+func (r *Rewards) pendingSeed(playerID string) (*storage.Seed, error) {
+	seed, err := r.seeds.PendingSeed(playerID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return r.newPendingSeed(playerID)
+	}
+	return seed, err
+}
+
+// newPendingSeed 新しいサーバーの種を、次に使う種として作って保存する。プレイヤーの種は入れ替える時に決まる。ロックを取った状態で呼ぶ
+func (r *Rewards) newPendingSeed(playerID string) (*storage.Seed, error) {
+	serverSeed, err := game.NewServerSeed()
+	if err != nil {
+		return nil, err
+	}
+	seed := &storage.Seed{
+		PlayerID:   playerID,
+		ServerSeed: serverSeed,
+		Hash:       game.HashSeed(serverSeed),
+		CreatedAt:  r.now(),
+		Pending:    true,
+	}
+	if err := r.seeds.SaveSeed(seed); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
+func validateClientSeed(seed string) error {
+	if len([]rune(seed)) > maxClientSeedLength {
+		return ErrInvalidClientSeed
+	}
+	for _, r := range seed {
+		if !unicode.IsPrint(r) {
+			return ErrInvalidClientSeed
+		}
+	}
+	return nil
+}
+
+// Inventory プレイヤーが持っているカードを、初めて手に入れた順に返す。付与した報酬の履歴から数える
+func (r *Rewards) Inventory(playerID string) ([]Item, error) {
+	grants, err := r.store.Grants(playerID)
+	if err != nil {
+		return nil, err
+	}
+	var items []Item
+	index := make(map[string]int)
+	for _, g := range grants {
+		i, ok := index[g.CardID]
+		if !ok {
+			i = len(items)
+			index[g.CardID] = i
+			items = append(items, Item{CardID: g.CardID})
+		}
+		items[i].Count++
+	}
+	return items, nil
+}
+
+// History プレイヤーに付与した報酬を古い順に返す
+func (r *Rewards) History(playerID string) ([]*storage.Grant, error) {
+	return r.store.Grants(playerID)
+}
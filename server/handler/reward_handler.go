@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"kazuki.matsumoto/reversi/gen/pb"
+	"kazuki.matsumoto/reversi/server/auth"
+	"kazuki.matsumoto/reversi/server/storage"
+)
+
+type RewardHandler struct {
+	pb.UnimplementedRewardServiceServer
+	rewards  *Rewards
+	profiles *Profiles
+}
+
+func NewRewardHandler(rewards *Rewards, profiles *Profiles) *RewardHandler {
+	return &RewardHandler{
+		rewards:  rewards,
+		profiles: profiles,
+	}
+}
+
+func (h *RewardHandler) GetInventory(ctx context.Context, req *pb.GetInventoryRequest) (*pb.GetInventoryResponse, error) {
+	prof, err := findProfile(h.profiles, req.GetPlayerId(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	items, err := h.rewards.Inventory(prof.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.GetInventoryResponse{
+		Items: make([]*pb.InventoryItem, 0, len(items)),
+	}
+	for _, item := range items {
+		res.Items = append(res.Items, &pb.InventoryItem{
+			CardId: item.CardID,
+			Count:  int32(item.Count),
+		})
+	}
+	return res, nil
+}
+
+func (h *RewardHandler) ListRewardHistory(ctx context.Context, req *pb.ListRewardHistoryRequest) (*pb.ListRewardHistoryResponse, error) {
+	if req.GetLimit() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must not be negative")
+	}
+	prof, err := findProfile(h.profiles, req.GetPlayerId(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	grants, err := h.rewards.History(prof.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.ListRewardHistoryResponse{}
+	for i := len(grants) - 1; i >= 0; i-- {
+		if req.GetLimit() > 0 && len(res.Rewards) >= int(req.GetLimit()) {
+			break
+		}
+		res.Rewards = append(res.Rewards, h.rewards.pbReward(grants[i]))
+	}
+	return res, nil
+}
+
+func (h *RewardHandler) GetOdds(ctx context.Context, req *pb.GetOddsRequest) (*pb.GetOddsResponse, error) {
+	res := &pb.GetOddsResponse{}
+	for _, p := range h.rewards.Table().Pools {
+		pool := &pb.OddsPool{
+			Name:      p.Name,
+			Mode:      string(p.Mode),
+			MinStreak: int32(p.MinStreak),
+			Pity:      int32(p.Pity),
+		}
+		for _, item := range p.Items {
+			pool.Items = append(pool.Items, &pb.OddsItem{
+				CardId:      item.CardID,
+				Rarity:      int32(item.Rarity),
+				Ratio:       int32(item.Ratio),
+				Probability: p.Probability(item),
+			})
+		}
+		res.Pools = append(res.Pools, pool)
+	}
+	return res, nil
+}
+
+func (h *RewardHandler) GetSeed(ctx context.Context, req *pb.GetSeedRequest) (*pb.GetSeedResponse, error) {
+	prof, err := findProfile(h.profiles, req.GetPlayerId(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	active, pending, err := h.rewards.Seeds(prof.ID)
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.GetSeedResponse{NextServerSeedHash: pending.Hash}
+	if active != nil {
+		res.Seed = pbSeed(active)
+		res.Seed.Nonce = active.Nonce
+	}
+	return res, nil
+}
+
+func (h *RewardHandler) RotateSeed(ctx context.Context, req *pb.RotateSeedRequest) (*pb.RotateSeedResponse, error) {
+	// 他のプレイヤーの種を入れ替えられないよう、トークンのプレイヤーの種だけを入れ替える
+	id, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "token is required")
+	}
+	revealed, next, pending, err := h.rewards.RotateSeed(id.PlayerID, req.GetClientSeed())
+	if errors.Is(err, ErrInvalidClientSeed) {
+		return nil, status.Errorf(codes.InvalidArgument, "client_seed must be 1 to %d printable characters", maxClientSeedLength)
+	}
+	if errors.Is(err, ErrSeedNotCommitted) {
+		return nil, status.Errorf(codes.FailedPrecondition, "the next seed hash has not been published yet")
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.RotateSeedResponse{
+		Next:               pbSeed(next),
+		NextServerSeedHash: pending.Hash,
+	}
+	if revealed != nil {
+		res.Revealed = pbSeed(revealed)
+	}
+	return res, nil
+}
+
+// pbReward 付与した報酬をFinishedEventやListRewardHistoryで返す形にする。付与していなければnil
+func (r *Rewards) pbReward(g *storage.Grant) *pb.Reward {
+	if g == nil {
+		return nil
+	}
+	res := &pb.Reward{
+		RoomId:      g.RoomID,
+		PlayerId:    g.PlayerID,
+		CardId:      g.CardID,
+		GrantedAtMs: g.GrantedAt.UnixMilli(),
+		Pool:        g.Pool,
+		Rarity:      int32(g.Rarity),
+		Pity:        g.Pity,
+		Nonce:       g.Nonce,
+	}
+	// 種を記録する前に付与した報酬には、種がない
+	if seed, err := r.SeedByHash(g.SeedHash); err == nil {
+		res.Seed = pbSeed(seed)
+	}
+	return res
+}
+
+// pbSeed 種を公開する形にする。サーバーの種は明かした後だけ含める
+func pbSeed(s *storage.Seed) *pb.Seed {
+	res := &pb.Seed{
+		ServerSeedHash: s.Hash,
+		ClientSeed:     s.ClientSeed,
+	}
+	if s.Revealed() {
+		res.ServerSeed = s.ServerSeed
+	}
+	return res
+}
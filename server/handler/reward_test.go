@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/server/reward"
+	"kazuki.matsumoto/reversi/server/storage"
+)
+
+func newTestRewards(tb testing.TB) *Rewards {
+	return NewRewards(storage.NewMemoryRewardStore(), storage.NewMemorySeedStore(), reward.Default(), newTestProfiles(tb))
+}
+
+// TestRotateSeedUsesDisclosedSeed 入れ替えで使い始める種は、プレイヤーの種を受け取る前にハッシュを公開した種
+func TestRotateSeedUsesDisclosedSeed(t *testing.T) {
+	r := newTestRewards(t)
+	disclosedAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return disclosedAt }
+
+	active, pending, err := r.Seeds("player")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if active != nil {
+		t.Fatalf("active seed = %+v before the first rotation, want nil", active)
+	}
+	disclosed := pending.Hash
+
+	rotatedAt := disclosedAt.Add(time.Minute)
+	r.now = func() time.Time { return rotatedAt }
+	revealed, next, pending, err := r.RotateSeed("player", "client-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revealed != nil {
+		t.Errorf("revealed = %+v on the first rotation, want nil", revealed)
+	}
+	if next.Hash != disclosed || next.ClientSeed != "client-1" || next.Pending {
+		t.Errorf("next = %+v, want the disclosed seed %v with client seed %q", next, disclosed, "client-1")
+	}
+	if !next.CreatedAt.Equal(disclosedAt) {
+		t.Errorf("next seed created at %v, want %v when its hash was disclosed", next.CreatedAt, disclosedAt)
+	}
+	if !pending.Pending || pending.Hash == disclosed || pending.ClientSeed != "" {
+		t.Errorf("pending = %+v, want a new seed without a client seed", pending)
+	}
+
+	// 次の入れ替えでは、今の種を明かして、前回公開した次の種を使い始める
+	revealed, next2, _, err := r.RotateSeed("player", "client-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revealed == nil || revealed.Hash != disclosed || !revealed.Revealed() || !game.VerifySeed(revealed.ServerSeed, disclosed) {
+		t.Errorf("revealed = %+v, want the seed with hash %v", revealed, disclosed)
+	}
+	if next2.Hash != pending.Hash {
+		t.Errorf("next = %v, want the seed disclosed by the previous rotation %v", next2.Hash, pending.Hash)
+	}
+	got, err := r.seeds.ActiveSeed("player")
+	if err != nil || got.Hash != next2.Hash {
+		t.Errorf("ActiveSeed = %+v, %v, want %v", got, err, next2.Hash)
+	}
+}
+
+func TestRotateSeedRejects(t *testing.T) {
+	r := newTestRewards(t)
+	// 次の種のハッシュを公開していなければ入れ替えない
+	if _, _, _, err := r.RotateSeed("player", "client"); !errors.Is(err, ErrSeedNotCommitted) {
+		t.Errorf("RotateSeed without a disclosed seed = %v, want ErrSeedNotCommitted", err)
+	}
+	if _, _, err := r.Seeds("player"); err != nil {
+		t.Fatal(err)
+	}
+	// 空のプレイヤーの種は、次の種を作る前からわかっているので受け付けない
+	for _, seed := range []string{"", "bad\nseed"} {
+		if _, _, _, err := r.RotateSeed("player", seed); !errors.Is(err, ErrInvalidClientSeed) {
+			t.Errorf("RotateSeed(%q) = %v, want ErrInvalidClientSeed", seed, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"sync"
+
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/server/auth"
+	"kazuki.matsumoto/reversi/server/storage"
+)
+
+// Session マッチング時に発行するセッション。通信が切れても、再接続時に同じ席に戻るために使う
+type Session struct {
+	Token  string
+	RoomID string
+	Player *game.Player
+}
+
+// SessionStore MatchingServiceで発行し、GameServiceのinterceptorで確かめるので両方で共有する
+type SessionStore struct {
+	sync.RWMutex
+	sessions map[string]*Session
+	signer   *auth.Signer  // トークンに署名する。GameServiceではリクエストの内容ではなく、トークンからプレイヤーを決める
+	store    storage.Store // サーバーを再起動しても再接続できるように、発行したトークンを保存する
+}
+
+func NewSessionStore(store storage.Store, signer *auth.Signer) *SessionStore {
+	return &SessionStore{
+		sessions: make(map[string]*Session),
+		signer:   signer,
+		store:    store,
+	}
+}
+
+// Issue 部屋とプレイヤーに対してセッションを発行する。トークンは部屋とプレイヤーに署名したもので、偽造できない
+func (s *SessionStore) Issue(roomID string, p *game.Player) (*Session, error) {
+	token, err := s.signer.Sign(roomID, p)
+	if err != nil {
+		return nil, err
+	}
+	sess := &Session{
+		Token:  token,
+		RoomID: roomID,
+		Player: p,
+	}
+
+	if err := s.store.SaveToken(roomID, p.Character, sess.Token); err != nil {
+		return nil, err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.sessions[sess.Token] = sess
+	return sess, nil
+}
+
+// Restore 保存しておいたトークンのセッションを登録し直す。サーバーの起動時に使う
+func (s *SessionStore) Restore(token string, roomID string, p *game.Player) {
+	s.Lock()
+	defer s.Unlock()
+	s.sessions[token] = &Session{
+		Token:  token,
+		RoomID: roomID,
+		Player: p,
+	}
+}
+
+// Verify トークンの署名を確かめ、トークンのプレイヤーを返す。片付けた部屋のトークンは使えない
+func (s *SessionStore) Verify(token string) (*auth.Identity, error) {
+	id, err := s.signer.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	s.RLock()
+	defer s.RUnlock()
+	if _, ok := s.sessions[token]; !ok {
+		return nil, auth.ErrInvalidToken
+	}
+	return id, nil
+}
+
+// Revoke 片付けた部屋のセッションを無効にする
+func (s *SessionStore) Revoke(roomIDs []string) {
+	s.Lock()
+	defer s.Unlock()
+
+	revoked := make(map[string]bool, len(roomIDs))
+	for _, id := range roomIDs {
+		revoked[id] = true
+	}
+	for token, sess := range s.sessions {
+		if revoked[sess.RoomID] {
+			delete(s.sessions, token)
+		}
+	}
+}
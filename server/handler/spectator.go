@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"log"
+
+	"kazuki.matsumoto/reversi/build"
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// watch streamを部屋の観戦者として登録し、対局の現在の状態を送る。以降は参加者と同じ通知を受け取る
+func (h *GameHandler) watch(stream pb.GameService_PlayServer, roomID string) error {
+	h.Lock()
+	defer h.Unlock()
+
+	if _, ok := h.players[stream]; ok {
+		return sendError(stream, pb.PlayResponse_ErrorEvent_INVALID_ACTION, "player can not watch")
+	}
+	if _, ok := h.registry.Room(roomID); !ok {
+		return reject(stream, ErrRoomNotFound)
+	}
+	g := h.games[roomID]
+	if g == nil {
+		return reject(stream, ErrRoomNotFound)
+	}
+	if h.closed[roomID] {
+		return sendError(stream, pb.PlayResponse_ErrorEvent_INVALID_ACTION, "spectators are not allowed")
+	}
+
+	// 別の部屋を観戦していた場合は移る
+	h.unwatch(stream)
+	h.watching[stream] = roomID
+	h.spectators[roomID] = append(h.spectators[roomID], stream)
+
+	return stream.Send(h.snapshot(roomID, g, nil))
+}
+
+// spectate 部屋の観戦者に通知する。観戦者への送信に失敗しても対局には影響させない。ロックを取った状態で呼ぶ
+func (h *GameHandler) spectate(roomID string, res *pb.PlayResponse) {
+	for _, s := range h.spectators[roomID] {
+		if err := s.Send(res); err != nil {
+			log.Printf("failed to send to spectator room_id=%v: %v", roomID, err)
+		}
+	}
+}
+
+// unwatch streamを観戦者から外す。ロックを取った状態で呼ぶ
+func (h *GameHandler) unwatch(stream pb.GameService_PlayServer) {
+	roomID, ok := h.watching[stream]
+	if !ok {
+		return
+	}
+	delete(h.watching, stream)
+
+	streams := h.spectators[roomID]
+	for i, s := range streams {
+		if s == stream {
+			h.spectators[roomID] = append(streams[:i], streams[i+1:]...)
+			break
+		}
+	}
+}
+
+// snapshot 対局の現在の状態。meは再接続したプレイヤーで、観戦者の場合はnil。ロックを取った状態で呼ぶ
+func (h *GameHandler) snapshot(roomID string, g *game.Game, me *game.Player) *pb.PlayResponse {
+	return &pb.PlayResponse{
+		Event: &pb.PlayResponse_Snapshot{
+			Snapshot: &pb.PlayResponse_SnapshotEvent{
+				Me:      build.PBPlayer(me),
+				Board:   build.PBBoard(g.Board),
+				Turn:    build.PBCharacter(g.Turn()),
+				Moves:   build.PBPlies(g.History()),
+				Started: g.Started(),
+				Clocks:  build.PBClocks(h.clocks[roomID]),
+			},
+		},
+	}
+}
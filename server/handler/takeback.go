@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"kazuki.matsumoto/reversi/build"
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/gen/pb"
+)
+
+// takeback 待ったを申し込む。相手がAIの場合はその場で承諾する
+func (h *GameHandler) takeback(stream pb.GameService_PlayServer, roomID string, req *game.Player) error {
+	h.Lock()
+	defer h.Unlock()
+
+	g, p, err := h.seated(stream, roomID, req)
+	if err != nil {
+		return reject(stream, err)
+	}
+	if g.Finished() {
+		return reject(stream, game.ErrGameFinished)
+	}
+	if _, ok := h.pending[roomID]; ok {
+		return sendError(stream, pb.PlayResponse_ErrorEvent_INVALID_ACTION, "takeback already requested")
+	}
+	if _, err := g.TakebackPly(p.Character); err != nil {
+		return reject(stream, err)
+	}
+
+	if bot, ok := h.bots[roomID]; ok && bot.Character != p.Character {
+		return h.resolveTakeback(roomID, g, p.Character, true)
+	}
+
+	h.pending[roomID] = p.Character
+	h.broadcast(roomID, &pb.PlayResponse{
+		Event: &pb.PlayResponse_TakebackRequested{
+			TakebackRequested: &pb.PlayResponse_TakebackRequestedEvent{
+				Player: build.PBPlayer(p),
+			},
+		},
+	})
+	return nil
+}
+
+// takebackReply 相手からの待ったに返答する
+func (h *GameHandler) takebackReply(stream pb.GameService_PlayServer, roomID string, req *game.Player, accept bool) error {
+	h.Lock()
+	defer h.Unlock()
+
+	g, p, err := h.seated(stream, roomID, req)
+	if err != nil {
+		return reject(stream, err)
+	}
+	// 自分で申し込んだ待ったには返答できない
+	requester, ok := h.pending[roomID]
+	if !ok || requester == p.Character {
+		return sendError(stream, pb.PlayResponse_ErrorEvent_INVALID_ACTION, "no takeback to reply")
+	}
+	delete(h.pending, roomID)
+
+	return h.resolveTakeback(roomID, g, requester, accept)
+}
+
+// resolveTakeback 承諾されていれば盤面を戻し、結果を参加者全員に通知する。ロックを取った状態で呼ぶ
+func (h *GameHandler) resolveTakeback(roomID string, g *game.Game, requester game.Character, accept bool) error {
+	event := &pb.PlayResponse_TakebackEvent{
+		Accepted: accept,
+		Ply:      int32(g.Cursor()),
+	}
+	if accept {
+		n, err := g.TakebackPly(requester)
+		if err != nil {
+			return err
+		}
+		if err := g.Jump(n); err != nil {
+			return err
+		}
+		event.Ply = int32(n)
+		// 戻した手番の側の時計を動かす。使った時間は戻らない
+		if clock, ok := h.clocks[roomID]; ok {
+			clock.Run(g.Turn())
+		}
+		h.save(roomID, g)
+	}
+	event.Board = build.PBBoard(g.Board)
+
+	h.broadcast(roomID, &pb.PlayResponse{
+		Event: &pb.PlayResponse_Takeback{
+			Takeback: event,
+		},
+	})
+	// 戻した結果AIの手番になった場合はAIに打たせる
+	if accept {
+		h.triggerBot(roomID, g)
+	}
+	return nil
+}
+
+// cancelTakeback 返答待ちの待ったがあれば、断られたものとして取り下げる。ロックを取った状態で呼ぶ
+func (h *GameHandler) cancelTakeback(roomID string) error {
+	requester, ok := h.pending[roomID]
+	if !ok {
+		return nil
+	}
+	delete(h.pending, roomID)
+	return h.resolveTakeback(roomID, h.games[roomID], requester, false)
+}
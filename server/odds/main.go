@@ -0,0 +1,90 @@
+// 報酬の抽選表の各プールから繰り返し抽選し、カードが出た回数が公開している提供割合どおりかをカイ二乗検定で確かめる。
+// 種を指定すると同じ抽選を再現できる。プールのどれかが提供割合どおりでなければ終了コード1で終わる。
+// -rng fairでは、サーバーが報酬の抽選に使う種から決まる乱数を、1回ごとに抽選の番号を進めて確かめる
+//
+//	go run ./server/odds -draws 1000000 -seed 1
+//	go run ./server/odds -rng fair -table /path/to/reward_table.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"kazuki.matsumoto/reversi/game"
+	"kazuki.matsumoto/reversi/server/reward"
+)
+
+func main() {
+	path := flag.String("table", "", "確かめる抽選表のJSONのファイル。空の場合は組み込みの抽選表")
+	draws := flag.Int("draws", 1000000, "プールごとに抽選する回数")
+	seed := flag.Int64("seed", 0, "乱数の種。0の場合は時刻から決める")
+	rng := flag.String("rng", "math", "確かめる乱数(math, fair)。fairはサーバーが報酬の抽選に使う乱数")
+	flag.Parse()
+	if *rng != "math" && *rng != "fair" {
+		fmt.Fprintf(os.Stderr, "unknown rng %q: math, fair\n", *rng)
+		os.Exit(2)
+	}
+
+	table := reward.Default()
+	if *path != "" {
+		var err error
+		if table, err = reward.Load(*path); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	fmt.Printf("draws=%v seed=%v rng=%v\n", *draws, *seed, *rng)
+	ok := true
+	for _, p := range table.Pools {
+		// プールごとに同じ種から始め、どのプールも単独で再現できるようにする
+		var rnd game.Random = rand.New(rand.NewSource(*seed))
+		if *rng == "fair" {
+			rnd = &nonceRandom{serverSeed: fmt.Sprint(*seed), clientSeed: "odds"}
+		}
+		r := reward.CheckOdds(p, *draws, rnd)
+		printReport(r)
+		ok = ok && r.OK()
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+func printReport(r *reward.OddsReport) {
+	verdict := "ok"
+	if !r.OK() {
+		verdict = "MISMATCH"
+	}
+	fmt.Printf("\npool=%v chi2=%.2f p=%.4f %v\n", r.Pool, r.ChiSquare, r.PValue, verdict)
+	fmt.Printf("  %-24v %6v %10v %12v %10v\n", "card", "rarity", "expected", "observed", "diff")
+	for _, o := range r.Observations {
+		observed := float64(o.Count) / float64(r.Draws)
+		expected := o.Expected / float64(r.Draws)
+		warn := ""
+		if o.Expected < 5 {
+			warn = " (too few draws to test)"
+		}
+		fmt.Printf("  %-24v %6v %9.4f%% %11.4f%% %+9.4f%%%v\n",
+			o.Item.CardID, o.Item.Rarity, 100*expected, 100*observed, 100*(observed-expected), warn)
+	}
+}
+
+// nonceRandom サーバーと同じく、1回の抽選ごとに番号を進めた種から乱数を作る
+type nonceRandom struct {
+	serverSeed string
+	clientSeed string
+	nonce      int64
+}
+
+func (r *nonceRandom) Intn(n int) int {
+	v := game.NewFairRandom(r.serverSeed, r.clientSeed, r.nonce).Intn(n)
+	r.nonce++
+	return v
+}
@@ -0,0 +1,117 @@
+package reward
+
+import (
+	"math"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+// Significance カイ二乗検定の有意水準。偶然に外れたと判定する確率を小さくする
+const Significance = 0.001
+
+// Probability カードが1回の抽選で出る確率。天井で抽選する場合は含めない
+func (p *Pool) Probability(item *Item) float64 {
+	total := 0
+	for _, i := range p.Items {
+		total += i.Ratio
+	}
+	return float64(item.Ratio) / float64(total)
+}
+
+// Observation 抽選を繰り返して、カードが出た回数と出るはずの回数
+type Observation struct {
+	Item     *Item
+	Count    int
+	Expected float64
+}
+
+// OddsReport 公開している提供割合と、実際に抽選して出た回数を比べた結果
+type OddsReport struct {
+	Pool         string
+	Draws        int
+	Observations []Observation
+	ChiSquare    float64 // カイ二乗統計量
+	PValue       float64 // 提供割合どおりに抽選して、これ以上ずれる確率。Significanceより小さければ提供割合どおりではない
+}
+
+// OK 出た回数が提供割合どおりと言えるか
+func (r *OddsReport) OK() bool {
+	return r.PValue >= Significance
+}
+
+// CheckOdds プールからrndでn回抽選し、カードが出た回数が提供割合どおりかをカイ二乗検定で確かめる。
+// 天井は含めず、提供割合だけを確かめる。出るはずの回数が5回未満のカードがあると、検定は当てにならない
+func CheckOdds(p *Pool, n int, rnd game.Random) *OddsReport {
+	counts := make(map[*Item]int, len(p.Items))
+	for i := 0; i < n; i++ {
+		counts[game.DrawFrom(p.Items, rnd)]++
+	}
+
+	r := &OddsReport{
+		Pool:  p.Name,
+		Draws: n,
+	}
+	for _, item := range p.Items {
+		o := Observation{
+			Item:     item,
+			Count:    counts[item],
+			Expected: float64(n) * p.Probability(item),
+		}
+		r.ChiSquare += (float64(o.Count) - o.Expected) * (float64(o.Count) - o.Expected) / o.Expected
+		r.Observations = append(r.Observations, o)
+	}
+	r.PValue = chiSquarePValue(r.ChiSquare, len(p.Items)-1)
+	return r
+}
+
+// chiSquarePValue 自由度dfのカイ二乗分布で、x以上になる確率
+func chiSquarePValue(x float64, df int) float64 {
+	// カードが1種類しかなければ、必ずそのカードが出るので外れようがない
+	if df <= 0 {
+		return 1
+	}
+	return gammaQ(float64(df)/2, x/2)
+}
+
+// gammaQ 正規化された上側不完全ガンマ関数Q(a, x)。xが小さい範囲は級数、大きい範囲は連分数で求める
+func gammaQ(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	lgamma, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lgamma)
+	if x < a+1 {
+		// P(a, x)の級数
+		sum, term := 1/a, 1/a
+		for n := 1; n < 1000 && math.Abs(term) > math.Abs(sum)*1e-15; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+		}
+		return 1 - prefix*sum
+	}
+	// Q(a, x)の連分数。Lentzの方法で計算する
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < 1000; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return prefix * h
+}
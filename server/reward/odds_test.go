@@ -0,0 +1,65 @@
+package reward
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+// oddsDraws プールごとの抽選回数。最も少ないカードでも出るはずの回数が十分に大きくなる
+const oddsDraws = 100000
+
+func TestCheckOddsDefaultTable(t *testing.T) {
+	sources := map[string]func() game.Random{
+		"math": func() game.Random { return rand.New(rand.NewSource(1)) },
+		"fair": func() game.Random { return game.NewFairRandom("odds-test-server-seed", "odds-test-client-seed", 0) },
+	}
+	for name, source := range sources {
+		for _, p := range Default().Pools {
+			r := CheckOdds(p, oddsDraws, source())
+			if !r.OK() {
+				t.Errorf("%v: pool %v does not match its ratios: chi2=%.2f p=%g", name, p.Name, r.ChiSquare, r.PValue)
+			}
+		}
+	}
+}
+
+func TestCheckOddsDetectsBias(t *testing.T) {
+	p := Default().Pools[0]
+	// 常に最初のカードを出す乱数は、提供割合どおりではない
+	if r := CheckOdds(p, oddsDraws, constRandom(0)); r.OK() {
+		t.Errorf("pool %v: biased draws passed: chi2=%.2f p=%g", p.Name, r.ChiSquare, r.PValue)
+	}
+}
+
+func TestPoolDrawPity(t *testing.T) {
+	for _, p := range Default().Pools {
+		if p.Pity == 0 {
+			continue
+		}
+		top := p.Top()
+		// 最後のカード(最高レアリティではない)を出し続ける乱数でも、Pity-1回外れた次は天井で最高レアリティになる
+		rnd := constRandom(math.MaxInt)
+		for missed := 0; missed < p.Pity-1; missed++ {
+			if _, pity := p.Draw(missed, rnd); pity {
+				t.Errorf("pool %v: pity hit after %d misses, want %d", p.Name, missed, p.Pity-1)
+			}
+		}
+		item, pity := p.Draw(p.Pity-1, rnd)
+		if !pity {
+			t.Errorf("pool %v: pity not hit after %d misses", p.Name, p.Pity-1)
+		}
+		if item.Rarity != top {
+			t.Errorf("pool %v: pity drew rarity %d, want %d", p.Name, item.Rarity, top)
+		}
+	}
+}
+
+// constRandom 常に同じ値を返す乱数。nを超える場合はn-1を返す
+type constRandom int
+
+func (r constRandom) Intn(n int) int {
+	return min(int(r), n-1)
+}
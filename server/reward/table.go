@@ -0,0 +1,223 @@
+// Package reward 対局に勝ったプレイヤーへの報酬の抽選表。
+// 抽選表はJSONのファイルから読み込むので、再コンパイルせずに提供割合を変更できる
+package reward
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+// ErrInvalidTable 抽選表の内容がおかしい
+var ErrInvalidTable = errors.New("invalid reward table")
+
+// defaultTable 抽選表のファイルを指定しない場合に使う抽選表。運用で変更する場合は、これを元にファイルを作る
+//
+//go:embed default.json
+var defaultTable []byte
+
+// Mode 報酬の対象になる対局の種類
+type Mode string
+
+const (
+	ModeAny   Mode = ""      // どの対局でも
+	ModeBot   Mode = "bot"   // AIとの対局
+	ModeRated Mode = "rated" // レーティングの対象になる、プレイヤー同士の対局
+)
+
+// Table 報酬の抽選表。上から順に条件に合うプールで抽選する
+type Table struct {
+	Pools []*Pool `json:"pools"`
+}
+
+// Pool 同じ条件で抽選するカードの集まり
+type Pool struct {
+	Name      string  `json:"name"`                 // 付与した報酬に記録する名前。天井はプールごとに数える
+	Mode      Mode    `json:"mode,omitempty"`       // 対象の対局の種類
+	MinStreak int     `json:"min_streak,omitempty"` // 対象になるのに必要な連勝数。レーティングの対象になる対局で数える
+	Pity      int     `json:"pity,omitempty"`       // 天井。最高レアリティが出ないまま、この回数目の抽選では必ず最高レアリティにする。0なら天井なし
+	Total     int     `json:"total,omitempty"`      // 提供割合の合計。指定すると、カードの提供割合の合計が一致しなければエラーにする。0なら確かめない
+	Items     []*Item `json:"items"`
+}
+
+// Item 抽選されるカード
+type Item struct {
+	CardID string `json:"card_id"`
+	Rarity int    `json:"rarity"` // レアリティ。大きいほど珍しい
+	Ratio  int    `json:"ratio"`  // 提供割合。プールの中の合計に対する割合で出る
+}
+
+func (i *Item) GetRatio() int {
+	return i.Ratio
+}
+
+// Condition 抽選する対局の条件
+type Condition struct {
+	Mode   Mode
+	Streak int // 直近の連勝数
+}
+
+// Default ファイルを指定しない場合の抽選表
+func Default() *Table {
+	t, err := Parse(bytes.NewReader(defaultTable))
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Load pathのJSONのファイルから抽選表を読み込む
+func Load(path string) (*Table, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	t, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+// Parse JSONから抽選表を読み込み、内容を確かめる。知らない項目があれば書き間違いとしてエラーにする
+func Parse(r io.Reader) (*Table, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	t := &Table{}
+	if err := dec.Decode(t); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTable, err)
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Validate 抽選表の内容を確かめる。どの対局でも抽選できるよう、最後のプールは条件なしにする
+func (t *Table) Validate() error {
+	if len(t.Pools) == 0 {
+		return fmt.Errorf("%w: no pools", ErrInvalidTable)
+	}
+	names := make(map[string]bool, len(t.Pools))
+	for i, p := range t.Pools {
+		if p.Name == "" {
+			return fmt.Errorf("%w: pools[%d]: name is required", ErrInvalidTable, i)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("%w: pool %q: duplicate name", ErrInvalidTable, p.Name)
+		}
+		names[p.Name] = true
+		if err := p.validate(); err != nil {
+			return fmt.Errorf("%w: pool %q: %v", ErrInvalidTable, p.Name, err)
+		}
+	}
+	if last := t.Pools[len(t.Pools)-1]; last.Mode != ModeAny || last.MinStreak != 0 {
+		return fmt.Errorf("%w: pool %q: the last pool must have no conditions", ErrInvalidTable, last.Name)
+	}
+	return nil
+}
+
+func (p *Pool) validate() error {
+	switch p.Mode {
+	case ModeAny, ModeBot, ModeRated:
+	default:
+		return fmt.Errorf("unknown mode %q", p.Mode)
+	}
+	if p.MinStreak < 0 {
+		return errors.New("min_streak must not be negative")
+	}
+	// 連勝数はAIとの対局では数えないので、決して選ばれない
+	if p.Mode == ModeBot && p.MinStreak > 0 {
+		return errors.New("min_streak can not be used with mode bot")
+	}
+	if p.Pity < 0 {
+		return errors.New("pity must not be negative")
+	}
+	if p.Total < 0 {
+		return errors.New("total must not be negative")
+	}
+	if len(p.Items) == 0 {
+		return errors.New("no items")
+	}
+
+	cards := make(map[string]bool, len(p.Items))
+	total := 0
+	for i, item := range p.Items {
+		if item.CardID == "" {
+			return fmt.Errorf("items[%d]: card_id is required", i)
+		}
+		if cards[item.CardID] {
+			return fmt.Errorf("card %q: duplicate card_id", item.CardID)
+		}
+		cards[item.CardID] = true
+		if item.Rarity < 0 {
+			return fmt.Errorf("card %q: rarity must not be negative", item.CardID)
+		}
+		if item.Ratio <= 0 {
+			return fmt.Errorf("card %q: ratio must be positive", item.CardID)
+		}
+		// 合計で乱数の範囲を決めるので、あふれないようにする
+		if item.Ratio > math.MaxInt32-total {
+			return errors.New("total ratio is too large")
+		}
+		total += item.Ratio
+	}
+	// 百分率などで書いた提供割合の書き間違いで、公開している割合と変わらないようにする
+	if p.Total > 0 && total != p.Total {
+		return fmt.Errorf("ratios sum to %d, want total %d", total, p.Total)
+	}
+	return nil
+}
+
+// Pool 条件に合う最初のプール。最後のプールは条件なしなので、必ず見つかる
+func (t *Table) Pool(c Condition) *Pool {
+	for _, p := range t.Pools {
+		if p.Mode != ModeAny && p.Mode != c.Mode {
+			continue
+		}
+		if c.Streak < p.MinStreak {
+			continue
+		}
+		return p
+	}
+	return t.Pools[len(t.Pools)-1]
+}
+
+// Top プールの最高レアリティ
+func (p *Pool) Top() int {
+	top := 0
+	for _, item := range p.Items {
+		top = max(top, item.Rarity)
+	}
+	return top
+}
+
+// Draw プールからrndで1枚抽選する。missedはこのプールで最高レアリティが出ずに続いた抽選の回数。
+// 天井に達した場合は、最高レアリティのカードの中から提供割合に従って抽選する。天井で抽選したかも返す
+func (p *Pool) Draw(missed int, rnd game.Random) (*Item, bool) {
+	if p.Pity > 0 && missed+1 >= p.Pity {
+		return game.DrawFrom(p.TopItems(), rnd), true
+	}
+	return game.DrawFrom(p.Items, rnd), false
+}
+
+// TopItems 最高レアリティのカード。天井で抽選する対象
+func (p *Pool) TopItems() []*Item {
+	top := p.Top()
+	var items []*Item
+	for _, item := range p.Items {
+		if item.Rarity == top {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,75 @@
+package reward
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// validItems 正しいカードの並び
+const validItems = `"items": [{"card_id": "rare", "rarity": 1, "ratio": 1}, {"card_id": "normal", "rarity": 0, "ratio": 3}]`
+
+func TestDefaultRoundTrip(t *testing.T) {
+	want := Default()
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Parse(strings.NewReader(string(b)))
+	if err != nil {
+		t.Fatalf("Parse(%s): %v", b, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %s, want the default table", b)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tbl, err := Parse(strings.NewReader(`{"pools": [
+		{"name": "streak", "mode": "rated", "min_streak": 3, "pity": 5, "total": 4, ` + validItems + `},
+		{"name": "default", ` + validItems + `}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tbl.Pools) != 2 {
+		t.Fatalf("%d pools, want 2", len(tbl.Pools))
+	}
+	p := tbl.Pools[0]
+	if p.Mode != ModeRated || p.MinStreak != 3 || p.Pity != 5 || p.Total != 4 || len(p.Items) != 2 {
+		t.Errorf("pool = %+v", p)
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for _, c := range []struct {
+		name  string
+		pools string
+	}{
+		{"ratios do not sum to the total", `{"name": "default", "total": 100, ` + validItems + `}`},
+		{"negative total", `{"name": "default", "total": -1, ` + validItems + `}`},
+		{"duplicate card", `{"name": "default", "items": [{"card_id": "a", "ratio": 1}, {"card_id": "a", "ratio": 2}]}`},
+		{"empty pool", `{"name": "default", "items": []}`},
+		{"pool without items", `{"name": "default"}`},
+		{"negative pity", `{"name": "default", "pity": -1, ` + validItems + `}`},
+		{"zero ratio", `{"name": "default", "items": [{"card_id": "a", "ratio": 0}]}`},
+		{"negative rarity", `{"name": "default", "items": [{"card_id": "a", "rarity": -1, "ratio": 1}]}`},
+		{"missing card id", `{"name": "default", "items": [{"ratio": 1}]}`},
+		{"overflowing ratios", `{"name": "default", "items": [{"card_id": "a", "ratio": 2147483647}, {"card_id": "b", "ratio": 1}]}`},
+		{"missing pool name", `{` + validItems + `}`},
+		{"duplicate pool name", `{"name": "default", "mode": "bot", ` + validItems + `}, {"name": "default", ` + validItems + `}`},
+		{"unknown mode", `{"name": "ranked", "mode": "ranked", ` + validItems + `}, {"name": "default", ` + validItems + `}`},
+		{"min_streak with bot", `{"name": "bot", "mode": "bot", "min_streak": 2, ` + validItems + `}, {"name": "default", ` + validItems + `}`},
+		{"negative min_streak", `{"name": "streak", "min_streak": -1, ` + validItems + `}, {"name": "default", ` + validItems + `}`},
+		{"last pool with conditions", `{"name": "rated", "mode": "rated", ` + validItems + `}`},
+		{"unknown field", `{"name": "default", "weight": 1, ` + validItems + `}`},
+		{"no pools", ``},
+	} {
+		_, err := Parse(strings.NewReader(`{"pools": [` + c.pools + `]}`))
+		if !errors.Is(err, ErrInvalidTable) {
+			t.Errorf("%v: Parse = %v, want ErrInvalidTable", c.name, err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package storage
+
+// FileStore JSON Linesのファイルに保存する。外部のサービスなしで使える。
+// 記録が更新されるたびに、その部屋の記録全体を1行として追記する。同じ部屋の記録は後の行が優先される。
+// 開く際に部屋ごとに最新の1行だけを残すよう書き直すので、ファイルは際限なく大きくはならない。
+// 書き込みは別のgoroutineで行うので、対局中に手を打つたびに保存してもディスクへの書き込みを待たない
+type FileStore struct {
+	*MemoryStore
+	out *backgroundAppender
+}
+
+// OpenFileStore pathのファイルを読み込んで開く。ファイルがなければ作成する
+func OpenFileStore(path string) (*FileStore, error) {
+	s := &FileStore{
+		MemoryStore: NewMemoryStore(),
+	}
+	err := readJSONLines(path, func(r *Record) {
+		s.records[r.Room.ID] = r
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 部屋ごとに最新の記録だけを書いたファイルで置き換える
+	records, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	if err := writeJSONLines(path, records); err != nil {
+		return nil, err
+	}
+
+	out, err := openAppender(path)
+	if err != nil {
+		return nil, err
+	}
+	s.out = newBackgroundAppender(out)
+	s.onSave = func(r *Record) error {
+		// 書き込むまでの間に記録が更新されても、保存した時点の内容を書く
+		return s.out.append(r.Room.ID, r.clone())
+	}
+	return s, nil
+}
+
+// Close まだ書き込んでいない記録を書き込んでから閉じる
+func (s *FileStore) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	return s.out.Close()
+}
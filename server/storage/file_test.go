@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+// TestFileStoreReopen 手を打つたびに保存した記録は、閉じた後に開き直すと最新のものが読める
+func TestFileStoreReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.jsonl")
+	s, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	black := &game.Player{ID: "black", Name: "black", Character: game.Black}
+	white := &game.Player{ID: "white", Name: "white", Character: game.White}
+	if err := s.SaveRoom(&game.Room{ID: "room", Host: black, Guest: white}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SaveToken("room", game.Black, "token"); err != nil {
+		t.Fatal(err)
+	}
+
+	g := game.NewGame(game.None)
+	if err := g.Sit(black); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Sit(white); err != nil {
+		t.Fatal(err)
+	}
+	g.Start()
+	moves, err := game.ParseTranscript("f5d6c3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range moves.History() {
+		if _, err := g.Move(m.X, m.Y, g.Turn()); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SaveGame("room", g, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := s.Get("room")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SaveGame("room", g, nil); !errors.Is(err, errAppenderClosed) {
+		t.Errorf("SaveGame after Close = %v, want errAppenderClosed", err)
+	}
+
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	got, err := reopened.Get("room")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Moves, g.History()) || !got.Started || got.Tokens[game.Black] != "token" {
+		t.Errorf("reopened record = %+v, want %+v", got, want)
+	}
+	if !got.UpdatedAt.Equal(want.UpdatedAt) {
+		t.Errorf("updated at %v, want %v", got.UpdatedAt, want.UpdatedAt)
+	}
+}
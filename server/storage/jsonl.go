@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// maxLineSize 1行の最大の長さ。1局分の棋譜や、プレイヤーのレーティングの履歴が収まれば良い
+const maxLineSize = 4 << 20
+
+// readJSONLines JSON Linesのファイルを1行ずつ読み込む。ファイルがなければ何もしない。
+// 書き込み中に止まった場合は最後の行が壊れていることがあるので、最後の行であれば捨てる
+func readJSONLines[T any](path string, fn func(v *T)) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	var broken error
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		// 壊れた行の後にも続いている場合は、ファイル自体がおかしい
+		if broken != nil {
+			return broken
+		}
+		v := new(T)
+		if err := json.Unmarshal(scanner.Bytes(), v); err != nil {
+			broken = fmt.Errorf("%s:%d: %w", path, line, err)
+			continue
+		}
+		fn(v)
+	}
+	return scanner.Err()
+}
+
+// writeJSONLines vsを1行ずつ書いたファイルで置き換える
+func writeJSONLines[T any](path string, vs []T) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, v := range vs {
+		if err := enc.Encode(v); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// appender JSON Linesのファイルに1行ずつ追記する
+type appender struct {
+	f   *os.File
+	enc *json.Encoder
+}
+
+func openAppender(path string) (*appender, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &appender{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+// append vを1行追記し、ディスクに書き込まれるまで待つ
+func (a *appender) append(v any) error {
+	if err := a.enc.Encode(v); err != nil {
+		return err
+	}
+	return a.f.Sync()
+}
+
+// errAppenderClosed 閉じた後に追記しようとした
+var errAppenderClosed = errors.New("appender is closed")
+
+// backgroundAppender 別のgoroutineでappenderに追記する。追記する側はディスクへの書き込みを待たない。
+// 書き込みが追いつかない間に同じキーの行が届いた場合は、最新の行だけを書く。
+// 溜まった行はまとめて書き、ディスクに書き込まれるまで待つのは1回にする
+type backgroundAppender struct {
+	sync.Mutex
+	out     *appender
+	pending map[string]any // キーごとの、まだ書いていない最新の行
+	order   []string       // pendingのキー。届いた順
+	wake    chan struct{}  // 行が届いたことを書き込むgoroutineに知らせる
+	done    chan struct{}  // 書き込むgoroutineが終わったら閉じる
+	closed  bool
+}
+
+func newBackgroundAppender(out *appender) *backgroundAppender {
+	a := &backgroundAppender{
+		out:     out,
+		pending: make(map[string]any),
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// append keyの行としてvを追記する。書き込みは待たないので、vは後から書き換えないものを渡す
+func (a *backgroundAppender) append(key string, v any) error {
+	a.Lock()
+	defer a.Unlock()
+	if a.closed {
+		return errAppenderClosed
+	}
+	if _, ok := a.pending[key]; !ok {
+		a.order = append(a.order, key)
+	}
+	a.pending[key] = v
+	select {
+	case a.wake <- struct{}{}:
+	default:
+		// すでに知らせてあれば、書き込むgoroutineが次に起きた時に一緒に書く
+	}
+	return nil
+}
+
+func (a *backgroundAppender) run() {
+	defer close(a.done)
+	for range a.wake {
+		a.flush()
+	}
+	a.flush()
+}
+
+// flush 溜まった行を書き、ディスクに書き込まれるまで待つ。失敗しても次の行は書き続ける
+func (a *backgroundAppender) flush() {
+	a.Lock()
+	pending, order := a.pending, a.order
+	a.pending, a.order = make(map[string]any), nil
+	a.Unlock()
+	if len(order) == 0 {
+		return
+	}
+
+	for _, key := range order {
+		if err := a.out.enc.Encode(pending[key]); err != nil {
+			log.Printf("failed to write %v to %v: %v", key, a.out.f.Name(), err)
+		}
+	}
+	if err := a.out.f.Sync(); err != nil {
+		log.Printf("failed to sync %v: %v", a.out.f.Name(), err)
+	}
+}
+
+// Close 溜まっている行を全て書いてから閉じる
+func (a *backgroundAppender) Close() error {
+	a.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.wake)
+	}
+	a.Unlock()
+	<-a.done
+	return a.out.Close()
+}
+
+func (a *appender) Close() error {
+	return a.f.Close()
+}
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+// MemoryStore メモリ上の保存先。サーバーを止めると消える
+type MemoryStore struct {
+	sync.RWMutex
+	records map[string]*Record
+	// onSave 記録が更新されるたびに呼ばれる。ファイルに書き出す場合に使う
+	onSave func(r *Record) error
+	now    func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]*Record),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) SaveRoom(room *game.Room) error {
+	s.Lock()
+	defer s.Unlock()
+
+	r, ok := s.records[room.ID]
+	if !ok {
+		r = &Record{
+			Tokens:    make(map[game.Character]string, 2),
+			CreatedAt: s.now(),
+		}
+		s.records[room.ID] = r
+	}
+	r.Room = cloneRoom(room)
+	return s.save(r)
+}
+
+func (s *MemoryStore) SaveToken(roomID string, c game.Character, token string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	r, ok := s.records[roomID]
+	if !ok {
+		return ErrNotFound
+	}
+	r.Tokens[c] = token
+	return s.save(r)
+}
+
+func (s *MemoryStore) SaveGame(roomID string, g *game.Game, clock *game.Clock) error {
+	s.Lock()
+	defer s.Unlock()
+
+	r, ok := s.records[roomID]
+	if !ok {
+		return ErrNotFound
+	}
+	r.Started = g.Started()
+	r.Moves = g.History()
+	if clock != nil {
+		r.Clocks = make(map[game.Character]time.Duration, 2)
+		for _, c := range []game.Character{game.Black, game.White} {
+			left, byoyomi := clock.Remaining(c)
+			if byoyomi {
+				// 秒読みに入っていれば、持ち時間は使い切っている
+				left = 0
+			}
+			r.Clocks[c] = left
+		}
+	}
+	if g.Finished() && r.Result == nil {
+		r.Result = &Result{
+			Termination: g.Termination(),
+			Winner:      g.Winner(),
+			Loser:       g.Loser(),
+			Black:       g.Board.Score(game.Black),
+			White:       g.Board.Score(game.White),
+			FinishedAt:  s.now(),
+		}
+	}
+	return s.save(r)
+}
+
+func (s *MemoryStore) Get(roomID string) (*Record, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	r, ok := s.records[roomID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return r.clone(), nil
+}
+
+func (s *MemoryStore) List() ([]*Record, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	records := make([]*Record, 0, len(s.records))
+	for _, r := range s.records {
+		records = append(records, r.clone())
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].CreatedAt.Equal(records[j].CreatedAt) {
+			return records[i].CreatedAt.Before(records[j].CreatedAt)
+		}
+		return records[i].Room.ID < records[j].Room.ID
+	})
+	return records, nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// save 更新日時を記録し、書き出し先があれば書き出す。ロックを取った状態で呼ぶ
+func (s *MemoryStore) save(r *Record) error {
+	r.UpdatedAt = s.now()
+	if s.onSave == nil {
+		return nil
+	}
+	return s.onSave(r)
+}
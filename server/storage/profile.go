@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"kazuki.matsumoto/reversi/game/rating"
+)
+
+// ErrNameTaken 同じ名前のプロフィールがすでにある
+var ErrNameTaken = errors.New("name already taken")
+
+// ProfileStore プレイヤーのプロフィールの保存先
+type ProfileStore interface {
+	// CreateProfile idと名前でプロフィールを作成する。同じ名前のプロフィールがあればErrNameTaken。
+	// registeredなら、名前をプレイヤートークンに結びつけたプロフィールにする
+	CreateProfile(id, name string, registered bool) (*Profile, error)
+	// SaveProfile プロフィールを更新する。なければErrNotFound
+	SaveProfile(p *Profile) error
+	// Profile IDでプロフィールを返す。なければErrNotFound
+	Profile(id string) (*Profile, error)
+	// ProfileByName 名前でプロフィールを返す。なければErrNotFound
+	ProfileByName(name string) (*Profile, error)
+	// Profiles 全てのプロフィールを作成した順に返す
+	Profiles() ([]*Profile, error)
+	Close() error
+}
+
+// Profile プレイヤーのプロフィールとレーティング
+type Profile struct {
+	ID         string
+	Name       string
+	Rating     rating.Rating
+	Wins       int
+	Losses     int
+	Draws      int
+	History    []RatingChange // レーティングの推移。古い順
+	Registered bool           // 名前を登録し、プレイヤートークンを発行する。ゲストはfalseで、トークンを持たない
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// RatingChange 1局ごとのレーティングの変化
+type RatingChange struct {
+	RoomID     string
+	OpponentID string
+	Score      float64       // rating.Win, rating.Draw, rating.Loss
+	Rating     rating.Rating // 対局後のレーティング
+	PlayedAt   time.Time
+}
+
+// Games レーティングの対象になった対局数
+func (p *Profile) Games() int {
+	return p.Wins + p.Losses + p.Draws
+}
+
+func (p *Profile) clone() *Profile {
+	c := *p
+	c.History = append([]RatingChange(nil), p.History...)
+	return &c
+}
+
+// MemoryProfileStore メモリ上のプロフィールの保存先。サーバーを止めると消える
+type MemoryProfileStore struct {
+	sync.RWMutex
+	profiles map[string]*Profile
+	names    map[string]string
+	// onSave プロフィールが更新されるたびに呼ばれる。ファイルに書き出す場合に使う
+	onSave func(p *Profile) error
+	now    func() time.Time
+}
+
+func NewMemoryProfileStore() *MemoryProfileStore {
+	return &MemoryProfileStore{
+		profiles: make(map[string]*Profile),
+		names:    make(map[string]string),
+		now:      time.Now,
+	}
+}
+
+func (s *MemoryProfileStore) CreateProfile(id, name string, registered bool) (*Profile, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.names[name]; ok {
+		return nil, ErrNameTaken
+	}
+	now := s.now()
+	p := &Profile{
+		ID:         id,
+		Name:       name,
+		Rating:     rating.Default(),
+		Registered: registered,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.put(p); err != nil {
+		return nil, err
+	}
+	return p.clone(), nil
+}
+
+func (s *MemoryProfileStore) SaveProfile(p *Profile) error {
+	s.Lock()
+	defer s.Unlock()
+
+	old, ok := s.profiles[p.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	// 名前の変更はできない
+	c := p.clone()
+	c.Name = old.Name
+	c.UpdatedAt = s.now()
+	return s.put(c)
+}
+
+func (s *MemoryProfileStore) Profile(id string) (*Profile, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	p, ok := s.profiles[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return p.clone(), nil
+}
+
+func (s *MemoryProfileStore) ProfileByName(name string) (*Profile, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	id, ok := s.names[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return s.profiles[id].clone(), nil
+}
+
+func (s *MemoryProfileStore) Profiles() ([]*Profile, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	profiles := make([]*Profile, 0, len(s.profiles))
+	for _, p := range s.profiles {
+		profiles = append(profiles, p.clone())
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		if !profiles[i].CreatedAt.Equal(profiles[j].CreatedAt) {
+			return profiles[i].CreatedAt.Before(profiles[j].CreatedAt)
+		}
+		return profiles[i].ID < profiles[j].ID
+	})
+	return profiles, nil
+}
+
+func (s *MemoryProfileStore) Close() error {
+	return nil
+}
+
+// put プロフィールを登録し、書き出し先があれば書き出す。ロックを取った状態で呼ぶ
+func (s *MemoryProfileStore) put(p *Profile) error {
+	if s.onSave != nil {
+		if err := s.onSave(p); err != nil {
+			return err
+		}
+	}
+	s.index(p)
+	return nil
+}
+
+// index プロフィールを検索できるようにする。ロックを取った状態で呼ぶ
+func (s *MemoryProfileStore) index(p *Profile) {
+	s.profiles[p.ID] = p
+	s.names[p.Name] = p.ID
+}
+
+// FileProfileStore JSON Linesのファイルにプロフィールを保存する。書き方はFileStoreと同じ
+type FileProfileStore struct {
+	*MemoryProfileStore
+	out *appender
+}
+
+// OpenFileProfileStore pathのファイルを読み込んで開く。ファイルがなければ作成する
+func OpenFileProfileStore(path string) (*FileProfileStore, error) {
+	s := &FileProfileStore{
+		MemoryProfileStore: NewMemoryProfileStore(),
+	}
+	err := readJSONLines(path, func(p *Profile) {
+		s.index(p)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	profiles, err := s.Profiles()
+	if err != nil {
+		return nil, err
+	}
+	if err := writeJSONLines(path, profiles); err != nil {
+		return nil, err
+	}
+
+	if s.out, err = openAppender(path); err != nil {
+		return nil, err
+	}
+	s.onSave = func(p *Profile) error {
+		return s.out.append(p)
+	}
+	return s, nil
+}
+
+func (s *FileProfileStore) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	return s.out.Close()
+}
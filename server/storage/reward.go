@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrAlreadyGranted 同じ対局の報酬をすでに付与している
+var ErrAlreadyGranted = errors.New("reward already granted")
+
+// RewardStore 対局の報酬の保存先。付与した報酬は消さずに全て残す
+type RewardStore interface {
+	// Grant 付与した報酬を記録する。同じ部屋で同じプレイヤーにすでに付与していればErrAlreadyGranted
+	Grant(g *Grant) error
+	// Granted 部屋でプレイヤーに付与した報酬を返す。なければErrNotFound
+	Granted(roomID, playerID string) (*Grant, error)
+	// Grants プレイヤーに付与した報酬を古い順に返す
+	Grants(playerID string) ([]*Grant, error)
+	Close() error
+}
+
+// Grant 1局ごとに付与した報酬。所持品と天井までの回数はこの履歴から数える
+type Grant struct {
+	RoomID    string
+	PlayerID  string
+	CardID    string
+	Pool      string // 抽選したプール
+	Rarity    int    // 抽選した時点のレアリティ
+	Pity      bool   // 天井に達して、最高レアリティのカードだけから抽選した
+	SeedHash  string // 抽選に使ったサーバーの種のハッシュ。種を明かした後は、プレイヤーが抽選をやり直して確かめられる
+	Nonce     int64  // 種の何回目の抽選か
+	GrantedAt time.Time
+}
+
+// MemoryRewardStore メモリ上の報酬の保存先。サーバーを止めると消える
+type MemoryRewardStore struct {
+	sync.RWMutex
+	grants  map[string][]*Grant          // プレイヤーごとの報酬。古い順
+	granted map[string]map[string]*Grant // 部屋ごと、プレイヤーごとの報酬
+	// onSave 報酬を付与するたびに呼ばれる。ファイルに書き出す場合に使う
+	onSave func(g *Grant) error
+}
+
+func NewMemoryRewardStore() *MemoryRewardStore {
+	return &MemoryRewardStore{
+		grants:  make(map[string][]*Grant),
+		granted: make(map[string]map[string]*Grant),
+	}
+}
+
+func (s *MemoryRewardStore) Grant(g *Grant) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.granted[g.RoomID][g.PlayerID]; ok {
+		return ErrAlreadyGranted
+	}
+	c := *g
+	if s.onSave != nil {
+		if err := s.onSave(&c); err != nil {
+			return err
+		}
+	}
+	s.index(&c)
+	return nil
+}
+
+func (s *MemoryRewardStore) Granted(roomID, playerID string) (*Grant, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	g, ok := s.granted[roomID][playerID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	c := *g
+	return &c, nil
+}
+
+func (s *MemoryRewardStore) Grants(playerID string) ([]*Grant, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	grants := make([]*Grant, 0, len(s.grants[playerID]))
+	for _, g := range s.grants[playerID] {
+		c := *g
+		grants = append(grants, &c)
+	}
+	return grants, nil
+}
+
+func (s *MemoryRewardStore) Close() error {
+	return nil
+}
+
+// index 報酬を検索できるようにする。ロックを取った状態で呼ぶ
+func (s *MemoryRewardStore) index(g *Grant) {
+	if s.granted[g.RoomID] == nil {
+		s.granted[g.RoomID] = make(map[string]*Grant)
+	}
+	s.granted[g.RoomID][g.PlayerID] = g
+	s.grants[g.PlayerID] = append(s.grants[g.PlayerID], g)
+}
+
+// FileRewardStore JSON Linesのファイルに報酬を保存する。
+// 報酬は書き換えないので、付与するたびに1行追記する。開く際に読めた行だけで書き直す
+type FileRewardStore struct {
+	*MemoryRewardStore
+	out *appender
+}
+
+// OpenFileRewardStore pathのファイルを読み込んで開く。ファイルがなければ作成する
+func OpenFileRewardStore(path string) (*FileRewardStore, error) {
+	s := &FileRewardStore{
+		MemoryRewardStore: NewMemoryRewardStore(),
+	}
+	var grants []*Grant
+	err := readJSONLines(path, func(g *Grant) {
+		// 同じ対局の報酬が重なっていても、最初の1行だけを数える
+		if _, ok := s.granted[g.RoomID][g.PlayerID]; !ok {
+			s.index(g)
+			grants = append(grants, g)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 書き込み中に止まって壊れた行を取り除いたファイルで置き換える
+	if err := writeJSONLines(path, grants); err != nil {
+		return nil, err
+	}
+	if s.out, err = openAppender(path); err != nil {
+		return nil, err
+	}
+	s.onSave = func(g *Grant) error {
+		return s.out.append(g)
+	}
+	return s, nil
+}
+
+func (s *FileRewardStore) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	return s.out.Close()
+}
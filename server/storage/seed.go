@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// SeedStore 報酬の抽選に使う種の保存先。明かした種も、プレイヤーが抽選を確かめられるように残す
+type SeedStore interface {
+	// SaveSeed 種を保存する。同じハッシュの種があれば置き換える
+	SaveSeed(s *Seed) error
+	// Seed ハッシュで種を返す。なければErrNotFound
+	Seed(hash string) (*Seed, error)
+	// ActiveSeed プレイヤーの抽選に使っている、まだ明かしていない種を返す。なければErrNotFound
+	ActiveSeed(playerID string) (*Seed, error)
+	// PendingSeed プレイヤーが次に種を入れ替える時に使う種を返す。なければErrNotFound
+	PendingSeed(playerID string) (*Seed, error)
+	Close() error
+}
+
+// Seed プレイヤーごとの抽選の種。サーバーの種はハッシュだけを公開し、入れ替える時に明かす
+type Seed struct {
+	PlayerID   string
+	ServerSeed string // 明かすまでは公開しない
+	Hash       string // サーバーの種のハッシュ。抽選の前から公開する
+	ClientSeed string // プレイヤーが決めた種。Pendingの間は空
+	Nonce      int64  // 次の抽選の番号。抽選するたびに増やす
+	CreatedAt  time.Time
+	RevealedAt *time.Time // サーバーの種を明かした時刻。まだ明かしていなければnil
+	// Pending 次に入れ替える時に使う種。ハッシュだけを先に公開しておき、プレイヤーの種を受け取った時に抽選に使い始める
+	Pending bool
+}
+
+// Revealed サーバーの種を明かしたか
+func (s *Seed) Revealed() bool {
+	return s.RevealedAt != nil
+}
+
+func (s *Seed) clone() *Seed {
+	c := *s
+	if s.RevealedAt != nil {
+		t := *s.RevealedAt
+		c.RevealedAt = &t
+	}
+	return &c
+}
+
+// MemorySeedStore メモリ上の種の保存先。サーバーを止めると消える
+type MemorySeedStore struct {
+	sync.RWMutex
+	seeds   map[string]*Seed // ハッシュごとの種
+	active  map[string]*Seed // プレイヤーごとの抽選に使っている種
+	pending map[string]*Seed // プレイヤーごとの次に使う種
+	// onSave 種が保存されるたびに呼ばれる。ファイルに書き出す場合に使う
+	onSave func(s *Seed) error
+}
+
+func NewMemorySeedStore() *MemorySeedStore {
+	return &MemorySeedStore{
+		seeds:   make(map[string]*Seed),
+		active:  make(map[string]*Seed),
+		pending: make(map[string]*Seed),
+	}
+}
+
+func (s *MemorySeedStore) SaveSeed(seed *Seed) error {
+	s.Lock()
+	defer s.Unlock()
+
+	c := seed.clone()
+	if s.onSave != nil {
+		if err := s.onSave(c); err != nil {
+			return err
+		}
+	}
+	s.index(c)
+	return nil
+}
+
+func (s *MemorySeedStore) Seed(hash string) (*Seed, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	seed, ok := s.seeds[hash]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return seed.clone(), nil
+}
+
+func (s *MemorySeedStore) ActiveSeed(playerID string) (*Seed, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	seed, ok := s.active[playerID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return seed.clone(), nil
+}
+
+func (s *MemorySeedStore) PendingSeed(playerID string) (*Seed, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	seed, ok := s.pending[playerID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return seed.clone(), nil
+}
+
+func (s *MemorySeedStore) Close() error {
+	return nil
+}
+
+// index 種を検索できるようにする。ロックを取った状態で呼ぶ
+func (s *MemorySeedStore) index(seed *Seed) {
+	s.seeds[seed.Hash] = seed
+	if seed.Pending {
+		s.pending[seed.PlayerID] = seed
+		return
+	}
+	// 次に使う種が抽選に使われ始めた
+	if p, ok := s.pending[seed.PlayerID]; ok && p.Hash == seed.Hash {
+		delete(s.pending, seed.PlayerID)
+	}
+	if seed.Revealed() {
+		if a, ok := s.active[seed.PlayerID]; ok && a.Hash == seed.Hash {
+			delete(s.active, seed.PlayerID)
+		}
+		return
+	}
+	s.active[seed.PlayerID] = seed
+}
+
+// list 全ての種を作成した順に返す。ロックを取った状態で呼ぶ
+func (s *MemorySeedStore) list() []*Seed {
+	seeds := make([]*Seed, 0, len(s.seeds))
+	for _, seed := range s.seeds {
+		seeds = append(seeds, seed)
+	}
+	sort.Slice(seeds, func(i, j int) bool {
+		if !seeds[i].CreatedAt.Equal(seeds[j].CreatedAt) {
+			return seeds[i].CreatedAt.Before(seeds[j].CreatedAt)
+		}
+		return seeds[i].Hash < seeds[j].Hash
+	})
+	return seeds
+}
+
+// FileSeedStore JSON Linesのファイルに種を保存する。書き方はFileStoreと同じで、同じハッシュの種は後の行が優先される
+type FileSeedStore struct {
+	*MemorySeedStore
+	out *appender
+}
+
+// OpenFileSeedStore pathのファイルを読み込んで開く。ファイルがなければ作成する
+func OpenFileSeedStore(path string) (*FileSeedStore, error) {
+	s := &FileSeedStore{
+		MemorySeedStore: NewMemorySeedStore(),
+	}
+	err := readJSONLines(path, func(seed *Seed) {
+		s.index(seed)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 種ごとに最新の1行だけを書いたファイルで置き換える
+	if err := writeJSONLines(path, s.list()); err != nil {
+		return nil, err
+	}
+
+	if s.out, err = openAppender(path); err != nil {
+		return nil, err
+	}
+	s.onSave = func(seed *Seed) error {
+		return s.out.append(seed)
+	}
+	return s, nil
+}
+
+func (s *FileSeedStore) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	return s.out.Close()
+}
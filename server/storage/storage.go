@@ -0,0 +1,91 @@
+// Package storage 部屋と対局の記録の保存先。サーバーを再起動しても対局の履歴が残るようにする
+package storage
+
+import (
+	"errors"
+	"time"
+
+	"kazuki.matsumoto/reversi/game"
+)
+
+// ErrNotFound 記録がない
+var ErrNotFound = errors.New("record not found")
+
+// Store 部屋と対局の記録の保存先
+type Store interface {
+	// SaveRoom 部屋を保存する。作成時と、ゲストが参加した時に呼ぶ
+	SaveRoom(room *game.Room) error
+	// SaveToken 再接続用のセッショントークンを保存する
+	SaveToken(roomID string, c game.Character, token string) error
+	// SaveGame 対局の棋譜、持ち時間、結果を保存する。持ち時間がない部屋ではclockはnil
+	SaveGame(roomID string, g *game.Game, clock *game.Clock) error
+	// Get 部屋の記録を返す。なければErrNotFound
+	Get(roomID string) (*Record, error)
+	// List 全ての記録を部屋を作成した順に返す
+	List() ([]*Record, error)
+	Close() error
+}
+
+// Record 部屋と対局の記録
+type Record struct {
+	Room      game.Room
+	Tokens    map[game.Character]string        // 色ごとの再接続用のセッショントークン
+	Started   bool                             // 対局が開始したか
+	Moves     []game.Ply                       // 初手からの棋譜
+	Clocks    map[game.Character]time.Duration // 最後に保存した時点の持ち時間の残り
+	Result    *Result                          // 対局の結果。終了していなければnil
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Result 対局の結果
+type Result struct {
+	Termination game.Termination
+	Winner      game.Character
+	Loser       game.Character
+	Black       int // 終了時の黒の石の数
+	White       int // 終了時の白の石の数
+	FinishedAt  time.Time
+}
+
+// Finished 対局が終了しているか
+func (r *Record) Finished() bool {
+	return r.Result != nil
+}
+
+// clone 保存先の記録を呼び出し元で書き換えられないように複製する
+func (r *Record) clone() *Record {
+	c := *r
+	c.Room = cloneRoom(&r.Room)
+	c.Tokens = make(map[game.Character]string, len(r.Tokens))
+	for k, v := range r.Tokens {
+		c.Tokens[k] = v
+	}
+	c.Moves = append([]game.Ply(nil), r.Moves...)
+	if r.Clocks != nil {
+		c.Clocks = make(map[game.Character]time.Duration, len(r.Clocks))
+		for k, v := range r.Clocks {
+			c.Clocks[k] = v
+		}
+	}
+	if r.Result != nil {
+		result := *r.Result
+		c.Result = &result
+	}
+	return &c
+}
+
+func cloneRoom(room *game.Room) game.Room {
+	c := *room
+	c.Host = clonePlayer(room.Host)
+	c.Guest = clonePlayer(room.Guest)
+	return c
+}
+
+func clonePlayer(p *game.Player) *game.Player {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	return &c
+}